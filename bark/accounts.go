@@ -0,0 +1,91 @@
+package bark
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"fmt"
+	"strings"
+)
+
+// bip85Purpose is the BIP85 purpose and bip85BIP39 the application number
+// for deriving BIP39 mnemonics.
+const (
+	bip85Purpose = 83696968
+	bip85BIP39   = 39
+)
+
+// AccountMnemonic returns the mnemonic of account accountIndex under
+// mnemonic. Account 0 is mnemonic itself; every other account gets its own
+// mnemonic derived with BIP85 (m/83696968'/39'/0'/{words}'/{accountIndex}'),
+// with the same number of words as mnemonic where BIP85 allows it and 24
+// otherwise.
+//
+// The derived mnemonic can be backed up and restored on its own, but it can
+// always be derived again from the parent mnemonic and the account index.
+func AccountMnemonic(mnemonic string, accountIndex uint32) (string, error) {
+	if accountIndex >= 1<<31 {
+		return "", fmt.Errorf("account index %d out of range", accountIndex)
+	}
+	seed, err := mnemonicToSeed(mnemonic)
+	if err != nil {
+		return "", err
+	}
+	if accountIndex == 0 {
+		return mnemonic, nil
+	}
+
+	words := len(strings.Fields(mnemonic))
+	if words != 12 && words != 18 && words != 24 {
+		words = 24
+	}
+
+	master, err := masterKey(seed)
+	if err != nil {
+		return "", err
+	}
+	return bip85Mnemonic(master, words, accountIndex)
+}
+
+// bip85Mnemonic derives the English BIP39 mnemonic with the given number of
+// words at index under master, as the BIP85 BIP39 application does.
+func bip85Mnemonic(master extendedKey, words int, index uint32) (string, error) {
+	const english = 0
+	entropy, err := bip85Entropy(master, bip85Purpose, bip85BIP39, english, uint32(words), index)
+	if err != nil {
+		return "", err
+	}
+	return entropyToMnemonic(entropy[:words*4/3])
+}
+
+// bip85Entropy returns the 64 bytes of BIP85 entropy for the hardened path
+// under master.
+func bip85Entropy(master extendedKey, path ...uint32) ([]byte, error) {
+	derived, err := master.derivePath(path...)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha512.New, []byte("bip-entropy-from-k"))
+	mac.Write(derived.key[:])
+	return mac.Sum(nil), nil
+}
+
+// CreateWalletAccount creates a wallet for account accountIndex of
+// mnemonic. Each account is a separate wallet, with its own database at
+// path, its own addresses and its own balance, that happens to share the
+// seed. Account 0 is the same wallet CreateWallet creates.
+func CreateWalletAccount(path string, mnemonic string, accountIndex uint32, config Config) (*Wallet, error) {
+	accountMnemonic, err := AccountMnemonic(mnemonic, accountIndex)
+	if err != nil {
+		return nil, err
+	}
+	return CreateWallet(path, accountMnemonic, config)
+}
+
+// OpenWalletAccount opens a wallet created by CreateWalletAccount.
+func OpenWalletAccount(path string, mnemonic string, accountIndex uint32) (*Wallet, error) {
+	accountMnemonic, err := AccountMnemonic(mnemonic, accountIndex)
+	if err != nil {
+		return nil, err
+	}
+	return OpenWallet(path, accountMnemonic)
+}
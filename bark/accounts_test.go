@@ -0,0 +1,99 @@
+package bark
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// bip85TestMaster is the master key of the BIP85 test vectors.
+const bip85TestMaster = "xprv9s21ZrQH143K2LBWUUQRFXhucrQqBpKdRRxNVq2zBqsx8HVqFk2uYo8kmbaLLHRdqtQpUm98uKfu3vca1LqdGhUtyoFnCNkfmXRyPXLjbKb"
+
+func parseTestXprv(t *testing.T, xprv string) extendedKey {
+	t.Helper()
+	payload, err := base58CheckDecode(xprv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Version, depth, parent fingerprint and child number come before the
+	// chain code, and the key is prefixed with a zero byte.
+	if len(payload) != 78 || payload[45] != 0 {
+		t.Fatalf("malformed xprv payload of %d bytes", len(payload))
+	}
+	var key extendedKey
+	copy(key.chainCode[:], payload[13:45])
+	copy(key.key[:], payload[46:])
+	return key
+}
+
+func TestBIP85Entropy(t *testing.T) {
+	master := parseTestXprv(t, bip85TestMaster)
+	tests := []struct {
+		path []uint32
+		want string
+	}{
+		{[]uint32{bip85Purpose, 0, 0}, "efecfbccffea313214232d29e71563d941229afb4338c21f9517c41aaa0d16f00b83d2a09ef747e7a64e8e2bd5a14869e693da66ce94ac2da570ab7ee48618f7"},
+		{[]uint32{bip85Purpose, 0, 1}, "70c6e3e8ebee8dc4c0dbba66076819bb8c09672527c4277ca8729532ad711872218f826919f6b67218adde99018a6df9095ab2b58d803b5b93ec9802085a690e"},
+	}
+	for _, tt := range tests {
+		entropy, err := bip85Entropy(master, tt.path...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(entropy); got != tt.want {
+			t.Errorf("entropy at %v = %s, want %s", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestBIP85Mnemonic(t *testing.T) {
+	master := parseTestXprv(t, bip85TestMaster)
+	tests := []struct {
+		words int
+		want  string
+	}{
+		{12, "girl mad pet galaxy egg matter matrix prison refuse sense ordinary nose"},
+		{18, "near account window bike charge season chef number sketch tomorrow excuse sniff circle vital hockey outdoor supply token"},
+		{24, "puppy ocean match cereal symbol another shed magic wrap hammer bulb intact gadget divorce twin tonight reason outdoor destroy simple truth cigar social volcano"},
+	}
+	for _, tt := range tests {
+		got, err := bip85Mnemonic(master, tt.words, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("%d words = %q, want %q", tt.words, got, tt.want)
+		}
+	}
+}
+
+func TestAccountMnemonic(t *testing.T) {
+	const parent = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	if got, err := AccountMnemonic(parent, 0); err != nil || got != parent {
+		t.Errorf("account 0 = %q, %v, want the parent mnemonic", got, err)
+	}
+	first, err := AccountMnemonic(parent, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _ := AccountMnemonic(parent, 1)
+	second, _ := AccountMnemonic(parent, 2)
+	if first != again {
+		t.Errorf("account 1 derived %q and then %q", first, again)
+	}
+	if first == second || first == parent {
+		t.Errorf("accounts 1 and 2 are not distinct: %q, %q", first, second)
+	}
+	if words := len(strings.Fields(first)); words != 12 {
+		t.Errorf("account 1 has %d words, want 12 like its parent", words)
+	}
+	if err := ValidateMnemonic(first); err != nil {
+		t.Errorf("account 1 mnemonic is invalid: %v", err)
+	}
+	if _, err := AccountMnemonic(parent, 1<<31); err == nil {
+		t.Error("AccountMnemonic accepted a hardened account index")
+	}
+	if _, err := AccountMnemonic("not a mnemonic", 1); err == nil {
+		t.Error("AccountMnemonic accepted an invalid mnemonic")
+	}
+}
@@ -0,0 +1,117 @@
+package bark
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// addressParams are the address encodings of a network.
+type addressParams struct {
+	bech32Hrp string
+	p2pkh     byte
+	p2sh      byte
+}
+
+var networkAddressParams = map[Network]addressParams{
+	NetworkBitcoin: {bech32Hrp: "bc", p2pkh: 0x00, p2sh: 0x05},
+	NetworkTestnet: {bech32Hrp: "tb", p2pkh: 0x6f, p2sh: 0xc4},
+	NetworkSignet:  {bech32Hrp: "tb", p2pkh: 0x6f, p2sh: 0xc4},
+	NetworkRegtest: {bech32Hrp: "bcrt", p2pkh: 0x6f, p2sh: 0xc4},
+}
+
+// ValidateBitcoinAddress checks offline that address is a well-formed
+// P2PKH, P2SH or segwit (bech32 or bech32m) address for network. It fails
+// with ErrErrorInvalidBitcoinAddress, or ErrErrorInvalidNetwork for an
+// unknown network. Testnet and signet share their address formats, so an
+// address for one is accepted for the other.
+func ValidateBitcoinAddress(address string, network Network) error {
+	params, ok := networkAddressParams[network]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrErrorInvalidNetwork, network)
+	}
+	address = strings.TrimSpace(address)
+	if hrp, _, _, err := bech32Decode(address); err == nil {
+		if hrp != params.bech32Hrp {
+			return fmt.Errorf("%w: %s address, not for %s", ErrErrorInvalidBitcoinAddress, hrp, network)
+		}
+		return validateSegwitAddress(address)
+	}
+
+	payload, err := base58CheckDecode(address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrErrorInvalidBitcoinAddress, err)
+	}
+	if len(payload) != 21 {
+		return fmt.Errorf("%w: bad length", ErrErrorInvalidBitcoinAddress)
+	}
+	if payload[0] != params.p2pkh && payload[0] != params.p2sh {
+		return fmt.Errorf("%w: version byte 0x%02x is not for %s", ErrErrorInvalidBitcoinAddress, payload[0], network)
+	}
+	return nil
+}
+
+// validateSegwitAddress checks the witness version and program of a bech32
+// decodable address as BIP173 and BIP350 require.
+func validateSegwitAddress(address string) error {
+	if len(address) > 90 {
+		return fmt.Errorf("%w: too long", ErrErrorInvalidBitcoinAddress)
+	}
+	_, data, variant, err := bech32Decode(address)
+	if err != nil || len(data) == 0 {
+		return fmt.Errorf("%w: %v", ErrErrorInvalidBitcoinAddress, err)
+	}
+	version := data[0]
+	if version > 16 {
+		return fmt.Errorf("%w: witness version %d", ErrErrorInvalidBitcoinAddress, version)
+	}
+	if (version == 0) != (variant == bech32Plain) {
+		return fmt.Errorf("%w: wrong checksum variant for witness version %d", ErrErrorInvalidBitcoinAddress, version)
+	}
+	program, err := convertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrErrorInvalidBitcoinAddress, err)
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return fmt.Errorf("%w: witness program of %d bytes", ErrErrorInvalidBitcoinAddress, len(program))
+	}
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return fmt.Errorf("%w: version 0 witness program of %d bytes", ErrErrorInvalidBitcoinAddress, len(program))
+	}
+	return nil
+}
+
+// base58CheckDecode decodes a base58 string and verifies and strips its
+// 4-byte checksum.
+func base58CheckDecode(s string) ([]byte, error) {
+	if s == "" {
+		return nil, fmt.Errorf("empty address")
+	}
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for _, c := range s {
+		digit := strings.IndexRune(base58Alphabet, c)
+		if digit < 0 {
+			return nil, fmt.Errorf("bad character %q", c)
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+	// Each leading '1' encodes a leading zero byte.
+	zeros := len(s) - len(strings.TrimLeft(s, "1"))
+	decoded := append(make([]byte, zeros), n.Bytes()...)
+	if len(decoded) < 5 {
+		return nil, fmt.Errorf("too short")
+	}
+	payload, checksum := decoded[:len(decoded)-4], decoded[len(decoded)-4:]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:4], checksum) {
+		return nil, fmt.Errorf("checksum mismatch")
+	}
+	return payload, nil
+}
@@ -0,0 +1,45 @@
+package bark
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateBitcoinAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		network Network
+		wantErr error
+	}{
+		{"mainnet taproot", "bc1p5d7rjq7g6rdk2yhzks9smlaqtedr4dekq08ge8ztwac72sfr9rusxg3297", NetworkBitcoin, nil},
+		{"signet taproot", "tb1pqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesf3hn0c", NetworkSignet, nil},
+		{"mainnet p2wpkh", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", NetworkBitcoin, nil},
+		{"mainnet p2pkh", "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", NetworkBitcoin, nil},
+		{"mainnet p2sh", "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", NetworkBitcoin, nil},
+		{"testnet p2pkh", "mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn", NetworkTestnet, nil},
+		{"surrounding space", "  bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4\n", NetworkBitcoin, nil},
+		{"mainnet bech32 on signet", "bc1p5d7rjq7g6rdk2yhzks9smlaqtedr4dekq08ge8ztwac72sfr9rusxg3297", NetworkSignet, ErrErrorInvalidBitcoinAddress},
+		{"signet taproot on mainnet", "tb1pqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesf3hn0c", NetworkBitcoin, ErrErrorInvalidBitcoinAddress},
+		{"mainnet p2pkh on regtest", "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", NetworkRegtest, ErrErrorInvalidBitcoinAddress},
+		{"taproot with bech32 checksum", "bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7k7grplx", NetworkBitcoin, ErrErrorInvalidBitcoinAddress},
+		{"bad base58 checksum", "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3", NetworkBitcoin, ErrErrorInvalidBitcoinAddress},
+		{"garbage", "not an address", NetworkBitcoin, ErrErrorInvalidBitcoinAddress},
+		{"empty", "", NetworkBitcoin, ErrErrorInvalidBitcoinAddress},
+		{"unknown network", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", Network("litecoin"), ErrErrorInvalidNetwork},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBitcoinAddress(tt.address, tt.network)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("ValidateBitcoinAddress: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateBitcoinAddress error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package bark
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetSendAllowlist restricts Send and SendOnchain to the given destinations;
+// anything else is rejected with ErrErrorDestinationNotAllowed before the
+// native library is called. An empty list removes the restriction, which is
+// the default. The list is persisted with the wallet.
+func (_self *Wallet) SetSendAllowlist(addresses []string) error {
+	allowed := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		address = normalizeDestination(address)
+		if address == "" {
+			return fmt.Errorf("%w: empty address in allowlist", ErrErrorInvalidBitcoinAddress)
+		}
+		allowed = append(allowed, address)
+	}
+
+	store, err := _self.metaStore()
+	if err != nil {
+		return err
+	}
+	return store.update(func(meta *walletMeta) error {
+		meta.SendAllowlist = allowed
+		return nil
+	})
+}
+
+func (_self *Wallet) checkDestination(destination string) error {
+	store, err := _self.metaStore()
+	if err != nil {
+		// Without metadata there is no allowlist to enforce.
+		return nil
+	}
+	var allowlist []string
+	store.view(func(meta *walletMeta) {
+		allowlist = meta.SendAllowlist
+	})
+	if len(allowlist) == 0 {
+		return nil
+	}
+	destination = normalizeDestination(destination)
+	for _, allowed := range allowlist {
+		if allowed == destination {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrErrorDestinationNotAllowed, destination)
+}
+
+// normalizeDestination trims an address and lower-cases it if it is all
+// upper case, as bech32 addresses in QR codes often are.
+func normalizeDestination(address string) string {
+	address = strings.TrimSpace(address)
+	if address == strings.ToUpper(address) {
+		address = strings.ToLower(address)
+	}
+	return address
+}
@@ -0,0 +1,40 @@
+package bark
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ArkInfoChange is a field of ArkInfo that differs between two snapshots.
+// Values are formatted as text; an unset MaxVtxoAmountSats is "none".
+type ArkInfoChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// Diff returns the fields of info that differ from prev, in field order. It
+// is empty if nothing changed.
+func (info ArkInfo) Diff(prev ArkInfo) []ArkInfoChange {
+	var changes []ArkInfoChange
+	add := func(field, old, new string) {
+		if old != new {
+			changes = append(changes, ArkInfoChange{Field: field, Old: old, New: new})
+		}
+	}
+	add("Network", string(prev.Network), string(info.Network))
+	add("AspPubkey", prev.AspPubkey, info.AspPubkey)
+	add("RoundIntervalSec", fmt.Sprint(prev.RoundIntervalSec), fmt.Sprint(info.RoundIntervalSec))
+	add("NbRoundNonces", fmt.Sprint(prev.NbRoundNonces), fmt.Sprint(info.NbRoundNonces))
+	add("VtxoExitDelta", fmt.Sprint(prev.VtxoExitDelta), fmt.Sprint(info.VtxoExitDelta))
+	add("VtxoExpiryDelta", fmt.Sprint(prev.VtxoExpiryDelta), fmt.Sprint(info.VtxoExpiryDelta))
+	add("MaxVtxoAmountSats", optionalAmount(prev.MaxVtxoAmountSats), optionalAmount(info.MaxVtxoAmountSats))
+	return changes
+}
+
+func optionalAmount(amount *uint64) string {
+	if amount == nil {
+		return "none"
+	}
+	return strconv.FormatUint(*amount, 10)
+}
@@ -0,0 +1,18 @@
+package bark
+
+// beforeAsp is called at the start of every wallet method that talks to the
+// ASP, other than Sync. It enforces the ASP pubkey pin and the request rate
+// limit.
+func (_self *Wallet) beforeAsp() error {
+	if _self.state == nil {
+		return nil
+	}
+	if err := _self.ensureAspChecked(_self.ArkInfo); err != nil {
+		return err
+	}
+	if mismatch := _self.state.aspMismatch.Load(); mismatch != nil {
+		return *mismatch
+	}
+	_self.state.aspLimiter.wait()
+	return nil
+}
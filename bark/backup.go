@@ -0,0 +1,231 @@
+package bark
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+)
+
+// backupMagic starts every backup blob and identifies its format version.
+var backupMagic = []byte("BARKBAK1")
+
+// backupKdfIterations is the PBKDF2-SHA256 work factor for backup keys.
+const backupKdfIterations = 600_000
+
+const (
+	backupSaltSize  = 16
+	backupCheckSize = 16
+	backupNonceSize = 12
+	backupHeaderLen = 8 + backupSaltSize + backupCheckSize + backupNonceSize
+)
+
+// walletBackup is the plaintext of a backup blob.
+type walletBackup struct {
+	Meta      walletMeta `json:"meta"`
+	Movements []Movement `json:"movements"`
+}
+
+// ExportBackup returns an encrypted backup of what the Go layer can
+// recover for the wallet: its config, name, labels and other settings, and
+// its movement history. It does not contain the mnemonic, which the user
+// keeps separately, nor vtxos or onchain funds, which only the native
+// database holds. Restore it with ImportBackup.
+//
+// The blob is encrypted with AES-256-GCM under a key derived from
+// passphrase with PBKDF2-SHA256.
+func (_self *Wallet) ExportBackup(passphrase string) ([]byte, error) {
+	store, err := _self.metaStore()
+	if err != nil {
+		return nil, err
+	}
+	var backup walletBackup
+	store.view(func(meta *walletMeta) {
+		err = cloneMeta(&backup.Meta, meta)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if backup.Movements, err = _self.Movements(); err != nil {
+		return nil, err
+	}
+	return encryptBackup(passphrase, backup)
+}
+
+// encryptBackup encodes backup and seals it under passphrase into a blob
+// that decryptBackup opens.
+func encryptBackup(passphrase string, backup walletBackup) ([]byte, error) {
+	plaintext, err := json.Marshal(backup)
+	if err != nil {
+		return nil, fmt.Errorf("encoding backup: %w", err)
+	}
+
+	header := make([]byte, backupHeaderLen)
+	copy(header, backupMagic)
+	salt := header[8 : 8+backupSaltSize]
+	check := header[8+backupSaltSize : 8+backupSaltSize+backupCheckSize]
+	nonce := header[backupHeaderLen-backupNonceSize:]
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	aead, keyCheck, err := backupCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	copy(check, keyCheck)
+	return aead.Seal(header, nonce, plaintext, header), nil
+}
+
+// ImportBackup creates a wallet at path from mnemonic and the config in a
+// blob from ExportBackup, then restores the backed-up settings and
+// movement history. Restored movements are kept like those from
+// ImportMovements and do not affect the balance; funds come back through
+// the native wallet's own recovery. Movements imported into the backed-up
+// wallet keep their ids; its native movements are numbered after them.
+//
+// It fails with ErrErrorWrongPassphrase if passphrase does not open the
+// blob, ErrErrorCorruptBackup if the blob is damaged, and
+// ErrErrorInvalidMnemonic if the backup was taken from a wallet with a
+// different mnemonic.
+func ImportBackup(path string, mnemonic string, passphrase string, blob []byte) (*Wallet, error) {
+	backup, err := decryptBackup(passphrase, blob)
+	if err != nil {
+		return nil, err
+	}
+	if backup.Meta.Config == nil {
+		return nil, fmt.Errorf("%w: backup has no config", ErrErrorUnknownConfig)
+	}
+	if fingerprint := backup.Meta.MnemonicFingerprint; fingerprint != nil {
+		matches, err := fingerprint.matches(mnemonic)
+		if err != nil {
+			return nil, err
+		}
+		if !matches {
+			return nil, fmt.Errorf("%w: mnemonic does not match the backup", ErrErrorInvalidMnemonic)
+		}
+	}
+
+	wallet, err := CreateWallet(path, mnemonic, *backup.Meta.Config)
+	if err != nil {
+		return nil, err
+	}
+	store, err := wallet.metaStore()
+	if err == nil {
+		err = store.update(func(meta *walletMeta) error {
+			return restoreMeta(meta, backup)
+		})
+	}
+	if err == nil {
+		wallet.state.aspLimiter.setRate(backup.Meta.MaxAspRequestsPerSec)
+	}
+	if err != nil {
+		wallet.Destroy()
+		return nil, err
+	}
+	return wallet, nil
+}
+
+func decryptBackup(passphrase string, blob []byte) (walletBackup, error) {
+	if len(blob) < backupHeaderLen || !bytes.Equal(blob[:8], backupMagic) {
+		return walletBackup{}, fmt.Errorf("%w: not a backup", ErrErrorCorruptBackup)
+	}
+	header := blob[:backupHeaderLen]
+	salt := header[8 : 8+backupSaltSize]
+	check := header[8+backupSaltSize : 8+backupSaltSize+backupCheckSize]
+	nonce := header[backupHeaderLen-backupNonceSize:]
+
+	aead, keyCheck, err := backupCipher(passphrase, salt)
+	if err != nil {
+		return walletBackup{}, err
+	}
+	if !hmac.Equal(check, keyCheck) {
+		return walletBackup{}, ErrErrorWrongPassphrase
+	}
+	plaintext, err := aead.Open(nil, nonce, blob[backupHeaderLen:], header)
+	if err != nil {
+		return walletBackup{}, fmt.Errorf("%w: %v", ErrErrorCorruptBackup, err)
+	}
+	var backup walletBackup
+	if err := json.Unmarshal(plaintext, &backup); err != nil {
+		return walletBackup{}, fmt.Errorf("%w: %v", ErrErrorCorruptBackup, err)
+	}
+	return backup, nil
+}
+
+// backupCipher derives the encryption key for passphrase and salt, and a
+// check value stored in the blob that tells a wrong passphrase apart from a
+// damaged blob.
+func backupCipher(passphrase string, salt []byte) (cipher.AEAD, []byte, error) {
+	keys, err := pbkdf2.Key(sha256.New, passphrase, salt, backupKdfIterations, 64)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, err := aes.NewCipher(keys[:32])
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	check := sha256.Sum256(keys[32:])
+	return aead, check[:backupCheckSize], nil
+}
+
+// restoreMeta replaces meta, the metadata of a wallet just created by
+// ImportBackup, with that of backup, keeping the new wallet's config and
+// mnemonic fingerprint. All of the backup's movements become imported
+// movements, with their rates, since the new wallet's native database
+// starts out empty and its ids would otherwise collide with theirs.
+func restoreMeta(meta *walletMeta, backup walletBackup) error {
+	restored := backup.Meta
+	restored.Config = meta.Config
+	restored.MnemonicFingerprint = meta.MnemonicFingerprint
+	restored.MovementRates = nil
+	restored.ImportedMovements = nil
+	restored.ImportedMovementRates = maps.Clone(backup.Meta.ImportedMovementRates)
+	addImportedMovements(&restored, backup.Meta.ImportedMovements)
+
+	var (
+		native []Movement
+		next   uint64
+	)
+	for _, movement := range restored.ImportedMovements {
+		next = max(next, uint64(movement.Id)+1)
+	}
+	for _, movement := range backup.Movements {
+		if !movement.Imported {
+			native = append(native, movement)
+		}
+	}
+	if next+uint64(len(native)) > math.MaxUint32+1 {
+		return fmt.Errorf("%w: movement ids out of range", ErrErrorCorruptBackup)
+	}
+	slices.SortFunc(native, func(a, b Movement) int { return cmp.Compare(a.Id, b.Id) })
+	for i := range native {
+		if rate, ok := backup.Meta.MovementRates[native[i].Id]; ok {
+			if restored.ImportedMovementRates == nil {
+				restored.ImportedMovementRates = make(map[uint32]MovementRate)
+			}
+			restored.ImportedMovementRates[uint32(next)] = rate
+		}
+		native[i].Id = uint32(next)
+		native[i].Rate = nil
+		next++
+	}
+	addImportedMovements(&restored, native)
+	*meta = restored
+	return nil
+}
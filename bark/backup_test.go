@@ -0,0 +1,132 @@
+package bark
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func testBackup() walletBackup {
+	config := validConfig()
+	within := uint32(144)
+	return walletBackup{
+		Meta: walletMeta{
+			Config:                  &config,
+			Name:                    "savings",
+			Labels:                  map[string]string{"tb1qaddress": "rent"},
+			AutoRefreshWithinBlocks: &within,
+			SendAllowlist:           []string{testArkAddress(1)},
+			DailySendLimitSat:       50_000,
+		},
+		Movements: testMovements(3),
+	}
+}
+
+func TestBackupRoundTrip(t *testing.T) {
+	backup := testBackup()
+	blob, err := encryptBackup("correct horse", backup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := decryptBackup("correct horse", blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decrypted, backup) {
+		t.Errorf("round trip = %+v, want %+v", decrypted, backup)
+	}
+}
+
+func TestBackupWrongPassphrase(t *testing.T) {
+	blob, err := encryptBackup("correct horse", testBackup())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = decryptBackup("battery staple", blob)
+	if !errors.Is(err, ErrErrorWrongPassphrase) {
+		t.Fatalf("error = %v, want ErrErrorWrongPassphrase", err)
+	}
+	if errors.Is(err, ErrErrorCorruptBackup) {
+		t.Errorf("wrong passphrase reported as a corrupt backup: %v", err)
+	}
+}
+
+func TestBackupCorrupt(t *testing.T) {
+	blob, err := encryptBackup("correct horse", testBackup())
+	if err != nil {
+		t.Fatal(err)
+	}
+	flipped := append([]byte(nil), blob...)
+	flipped[len(flipped)-1] ^= 1
+	renamed := append([]byte(nil), blob...)
+	renamed[0] = 'X'
+
+	tests := []struct {
+		name string
+		blob []byte
+	}{
+		{"empty", nil},
+		{"truncated header", blob[:backupHeaderLen-1]},
+		{"wrong magic", renamed},
+		{"flipped ciphertext bit", flipped},
+		{"truncated ciphertext", blob[:len(blob)-1]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decryptBackup("correct horse", tt.blob)
+			if !errors.Is(err, ErrErrorCorruptBackup) {
+				t.Errorf("error = %v, want ErrErrorCorruptBackup", err)
+			}
+		})
+	}
+}
+
+func TestRestoreMetaMovements(t *testing.T) {
+	backup := testBackup()
+	backup.Meta.ImportedMovements = []Movement{{Id: 1, Kind: MovementKindBoard}, {Id: 4, Kind: MovementKindBoard}}
+	backup.Meta.ImportedMovementRates = map[uint32]MovementRate{4: {Currency: "EUR", Rate: 1}}
+	backup.Meta.MovementRates = map[uint32]MovementRate{2: {Currency: "USD", Rate: 2}}
+	// As ExportBackup lists them: native ids 3 to 1, then the imported ones.
+	imported, _ := movementMetadata(&backup.Meta)
+	backup.Movements = append(testMovements(3), imported...)
+
+	wallet, _ := newTestWallet(t)
+	withTestMeta(t, wallet)
+	err := wallet.state.meta.update(func(meta *walletMeta) error {
+		return restoreMeta(meta, backup)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The restored wallet's own movements reuse the ids 1 to 3.
+	movements := wallet.withMetadata(testMovements(3))
+	sortMovements(movements, SortKeyId, false)
+	type entry struct {
+		id       uint32
+		imported bool
+		currency string
+	}
+	var got []entry
+	for _, movement := range movements {
+		e := entry{id: movement.Id, imported: movement.Imported}
+		if movement.Rate != nil {
+			e.currency = movement.Rate.Currency
+		}
+		got = append(got, e)
+	}
+	want := []entry{
+		{1, false, ""},
+		{1, true, ""},
+		{2, false, ""},
+		{3, false, ""},
+		{4, true, "EUR"},
+		// The backed-up wallet's native movements 1 to 3.
+		{5, true, ""},
+		{6, true, "USD"},
+		{7, true, ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
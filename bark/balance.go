@@ -0,0 +1,63 @@
+package bark
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// TotalBalance combines WalletBalance and OnchainBalance.
+type TotalBalance struct {
+	// OffchainSpendableSat is WalletBalance.SpendableSat.
+	OffchainSpendableSat uint64
+	// OnchainSpendableSat is OnchainBalance.TrustedSpendableSat.
+	OnchainSpendableSat uint64
+	// SpendableSat is the sum of the two spendable amounts above.
+	SpendableSat            uint64
+	PendingLightningSendSat uint64
+	PendingExitSat          uint64
+	// GrandTotalSat is SpendableSat plus both pending amounts.
+	GrandTotalSat uint64
+}
+
+// TotalSpendable returns the wallet's offchain and onchain spendable
+// balances along with the amounts still pending in lightning sends and
+// exits. It fails with ErrErrorBalanceOverflow if a sum does not fit in a
+// uint64.
+func (_self *Wallet) TotalSpendable() (TotalBalance, error) {
+	offchain, err := _self.WalletBalance()
+	if err != nil {
+		return TotalBalance{}, err
+	}
+	onchain, err := _self.OnchainBalance()
+	if err != nil {
+		return TotalBalance{}, err
+	}
+	return totalBalance(offchain, onchain)
+}
+
+func totalBalance(offchain WalletBalance, onchain OnchainBalance) (TotalBalance, error) {
+	total := TotalBalance{
+		OffchainSpendableSat:    offchain.SpendableSat,
+		OnchainSpendableSat:     onchain.TrustedSpendableSat,
+		PendingLightningSendSat: offchain.PendingLightningSendSat,
+		PendingExitSat:          offchain.PendingExitSat,
+	}
+	var ok bool
+	if total.SpendableSat, ok = addSat(total.OffchainSpendableSat, total.OnchainSpendableSat); !ok {
+		return TotalBalance{}, fmt.Errorf("%w: spendable", ErrErrorBalanceOverflow)
+	}
+	grand := total.SpendableSat
+	for _, pending := range []uint64{total.PendingLightningSendSat, total.PendingExitSat} {
+		if grand, ok = addSat(grand, pending); !ok {
+			return TotalBalance{}, fmt.Errorf("%w: grand total", ErrErrorBalanceOverflow)
+		}
+	}
+	total.GrandTotalSat = grand
+	return total, nil
+}
+
+// addSat returns a+b and whether it fits in a uint64.
+func addSat(a, b uint64) (uint64, bool) {
+	sum, carry := bits.Add64(a, b, 0)
+	return sum, carry == 0
+}
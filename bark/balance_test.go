@@ -0,0 +1,66 @@
+package bark
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestTotalBalance(t *testing.T) {
+	offchain := WalletBalance{SpendableSat: 100_000, PendingLightningSendSat: 2_000, PendingExitSat: 30_000}
+	onchain := OnchainBalance{TrustedSpendableSat: 50_000, TotalSat: 55_000}
+	total, err := totalBalance(offchain, onchain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := TotalBalance{
+		OffchainSpendableSat:    100_000,
+		OnchainSpendableSat:     50_000,
+		SpendableSat:            150_000,
+		PendingLightningSendSat: 2_000,
+		PendingExitSat:          30_000,
+		GrandTotalSat:           182_000,
+	}
+	if total != want {
+		t.Errorf("totalBalance = %+v, want %+v", total, want)
+	}
+}
+
+func TestTotalBalanceAtLimit(t *testing.T) {
+	offchain := WalletBalance{SpendableSat: math.MaxUint64 - 10, PendingExitSat: 10}
+	total, err := totalBalance(offchain, OnchainBalance{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total.GrandTotalSat != math.MaxUint64 {
+		t.Errorf("GrandTotalSat = %d, want MaxUint64", total.GrandTotalSat)
+	}
+}
+
+func TestTotalBalanceOverflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		offchain WalletBalance
+		onchain  OnchainBalance
+		wantSum  string
+	}{
+		{"spendable", WalletBalance{SpendableSat: math.MaxUint64}, OnchainBalance{TrustedSpendableSat: 1}, "spendable"},
+		{"pending lightning", WalletBalance{SpendableSat: math.MaxUint64, PendingLightningSendSat: 1}, OnchainBalance{}, "grand total"},
+		{"pending exit", WalletBalance{SpendableSat: math.MaxUint64 - 1, PendingLightningSendSat: 1, PendingExitSat: 1}, OnchainBalance{}, "grand total"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			total, err := totalBalance(tt.offchain, tt.onchain)
+			if !errors.Is(err, ErrErrorBalanceOverflow) {
+				t.Fatalf("error = %v, want ErrErrorBalanceOverflow", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantSum) {
+				t.Errorf("error %q does not name the %s sum", err, tt.wantSum)
+			}
+			if total != (TotalBalance{}) {
+				t.Errorf("total = %+v alongside the error, want zero", total)
+			}
+		})
+	}
+}
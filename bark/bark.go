@@ -10,6 +10,7 @@ import (
 	"io"
 	"math"
 	"runtime"
+	"sync"
 	"sync/atomic"
 	"unsafe"
 )
@@ -104,17 +105,32 @@ type BufWriter[GoType any] interface {
 	Write(writer io.Writer, value GoType)
 }
 
+// lowerBufferPool holds the buffers LowerIntoRustBuffer writes into. The
+// native side copies the bytes into a RustBuffer of its own, so a pooled
+// buffer never refers to Rust memory.
+var lowerBufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// maxPooledLowerBuffer caps the capacity of buffers returned to
+// lowerBufferPool, so one large value does not pin its buffer for good.
+const maxPooledLowerBuffer = 64 << 10
+
 func LowerIntoRustBuffer[GoType any](bufWriter BufWriter[GoType], value GoType) C.RustBuffer {
 	// This might be not the most efficient way but it does not require knowing allocation size
 	// beforehand
-	var buffer bytes.Buffer
-	bufWriter.Write(&buffer, value)
+	buffer := lowerBufferPool.Get().(*bytes.Buffer)
+	defer func() {
+		if buffer.Cap() <= maxPooledLowerBuffer {
+			buffer.Reset()
+			lowerBufferPool.Put(buffer)
+		}
+	}()
+	bufWriter.Write(buffer, value)
 
-	bytes, err := io.ReadAll(&buffer)
-	if err != nil {
-		panic(fmt.Errorf("reading written data: %w", err))
-	}
-	return bytesToRustBuffer(bytes)
+	// The bytes are copied into the RustBuffer before the buffer goes back
+	// to the pool, so they can be handed over without copying them first.
+	return bytesToRustBuffer(buffer.Bytes())
 }
 
 func LiftFromRustBuffer[GoType any](bufReader BufReader[GoType], rbuf RustBufferI) GoType {
@@ -129,10 +145,11 @@ func LiftFromRustBuffer[GoType any](bufReader BufReader[GoType], rbuf RustBuffer
 	return item
 }
 
-func rustCallWithError[E any, U any](converter BufReader[*E], callback func(*C.RustCallStatus) U) (U, *E) {
+func rustCallWithError[E any, U any](converter BufReader[*E], callback func(*C.RustCallStatus) U) (returnValue U, err *E) {
+	defer recoverRustPanic(&err)
 	var status C.RustCallStatus
-	returnValue := callback(&status)
-	err := checkCallStatus(converter, status)
+	returnValue = callback(&status)
+	err = checkCallStatus(converter, status)
 	return returnValue, err
 }
 
@@ -335,233 +352,50 @@ func init() {
 	uniffiCheckChecksums()
 }
 
-func uniffiCheckChecksums() {
-	// Get the bindings contract version from our ComponentInterface
-	bindingsContractVersion := 26
-	// Get the scaffolding contract version by calling the into the dylib
-	scaffoldingContractVersion := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint32_t {
+// uniffiBindingsContractVersion is the UniFFI contract version the bindings
+// were generated for.
+const uniffiBindingsContractVersion = 26
+
+// uniffiScaffoldingContractVersion returns the contract version of the
+// loaded library.
+func uniffiScaffoldingContractVersion() uint32 {
+	return uint32(rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint32_t {
 		return C.ffi_bark_uniffi_contract_version()
-	})
-	if bindingsContractVersion != int(scaffoldingContractVersion) {
-		// If this happens try cleaning and rebuilding your project
-		panic("bark: UniFFI contract version mismatch")
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_func_create_wallet()
-		})
-		if checksum != 59629 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_func_create_wallet: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_func_open_wallet()
-		})
-		if checksum != 15440 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_func_open_wallet: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_ark_info()
-		})
-		if checksum != 5686 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_ark_info: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_board_all()
-		})
-		if checksum != 5752 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_board_all: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_bolt11_invoice()
-		})
-		if checksum != 65315 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_bolt11_invoice: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_claim_bolt11_payment()
-		})
-		if checksum != 37734 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_claim_bolt11_payment: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_exit_all()
-		})
-		if checksum != 45736 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_exit_all: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_exit_status()
-		})
-		if checksum != 1084 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_exit_status: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_lookup_invoice()
-		})
-		if checksum != 30810 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_lookup_invoice: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_maintenance()
-		})
-		if checksum != 48568 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_maintenance: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_movements()
-		})
-		if checksum != 12620 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_movements: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_new_address()
-		})
-		if checksum != 11647 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_new_address: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_offboard_all()
-		})
-		if checksum != 38640 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_offboard_all: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_onchain_address()
-		})
-		if checksum != 45797 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_onchain_address: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_onchain_balance()
-		})
-		if checksum != 23885 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_onchain_balance: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_onchain_transactions()
-		})
-		if checksum != 57700 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_onchain_transactions: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_pay_bolt11()
-		})
-		if checksum != 50495 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_pay_bolt11: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_refresh_all()
-		})
-		if checksum != 16084 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_refresh_all: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_send()
-		})
-		if checksum != 55929 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_send: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_send_onchain()
-		})
-		if checksum != 399 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_send_onchain: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_sync()
-		})
-		if checksum != 20192 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_sync: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_utxos()
-		})
-		if checksum != 29454 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_utxos: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_vtxos()
-		})
-		if checksum != 31673 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_vtxos: UniFFI API checksum mismatch")
-		}
-	}
-	{
-		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
-			return C.uniffi_bark_checksum_method_wallet_wallet_balance()
-		})
-		if checksum != 32002 {
-			// If this happens try cleaning and rebuilding your project
-			panic("bark: uniffi_bark_checksum_method_wallet_wallet_balance: UniFFI API checksum mismatch")
-		}
-	}
+	}))
+}
+
+// uniffiChecksum is an API checksum the bindings were generated against.
+type uniffiChecksum struct {
+	symbol   string
+	checksum func() uint16
+	want     uint16
+}
+
+var uniffiChecksums = []uniffiChecksum{
+	{"uniffi_bark_checksum_func_create_wallet", func() uint16 { return uint16(C.uniffi_bark_checksum_func_create_wallet()) }, 59629},
+	{"uniffi_bark_checksum_func_open_wallet", func() uint16 { return uint16(C.uniffi_bark_checksum_func_open_wallet()) }, 15440},
+	{"uniffi_bark_checksum_method_wallet_ark_info", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_ark_info()) }, 5686},
+	{"uniffi_bark_checksum_method_wallet_board_all", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_board_all()) }, 5752},
+	{"uniffi_bark_checksum_method_wallet_bolt11_invoice", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_bolt11_invoice()) }, 65315},
+	{"uniffi_bark_checksum_method_wallet_claim_bolt11_payment", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_claim_bolt11_payment()) }, 37734},
+	{"uniffi_bark_checksum_method_wallet_exit_all", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_exit_all()) }, 45736},
+	{"uniffi_bark_checksum_method_wallet_exit_status", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_exit_status()) }, 1084},
+	{"uniffi_bark_checksum_method_wallet_lookup_invoice", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_lookup_invoice()) }, 30810},
+	{"uniffi_bark_checksum_method_wallet_maintenance", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_maintenance()) }, 48568},
+	{"uniffi_bark_checksum_method_wallet_movements", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_movements()) }, 12620},
+	{"uniffi_bark_checksum_method_wallet_new_address", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_new_address()) }, 11647},
+	{"uniffi_bark_checksum_method_wallet_offboard_all", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_offboard_all()) }, 38640},
+	{"uniffi_bark_checksum_method_wallet_onchain_address", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_onchain_address()) }, 45797},
+	{"uniffi_bark_checksum_method_wallet_onchain_balance", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_onchain_balance()) }, 23885},
+	{"uniffi_bark_checksum_method_wallet_onchain_transactions", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_onchain_transactions()) }, 57700},
+	{"uniffi_bark_checksum_method_wallet_pay_bolt11", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_pay_bolt11()) }, 50495},
+	{"uniffi_bark_checksum_method_wallet_refresh_all", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_refresh_all()) }, 16084},
+	{"uniffi_bark_checksum_method_wallet_send", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_send()) }, 55929},
+	{"uniffi_bark_checksum_method_wallet_send_onchain", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_send_onchain()) }, 399},
+	{"uniffi_bark_checksum_method_wallet_sync", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_sync()) }, 20192},
+	{"uniffi_bark_checksum_method_wallet_utxos", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_utxos()) }, 29454},
+	{"uniffi_bark_checksum_method_wallet_vtxos", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_vtxos()) }, 31673},
+	{"uniffi_bark_checksum_method_wallet_wallet_balance", func() uint16 { return uint16(C.uniffi_bark_checksum_method_wallet_wallet_balance()) }, 32002},
 }
 
 type FfiConverterUint16 struct{}
@@ -759,18 +593,33 @@ func (ffiObject *FfiObject) incrementPointer(debugName string) unsafe.Pointer {
 	})
 }
 
+// clonePointer returns a new strong reference to the object for the caller
+// to own, as the native side expects of an object passed by value: it
+// takes over the reference and releases it when done. The call counter is
+// only held while cloning, which is enough because the clone keeps the
+// object alive on its own, even if this handle is destroyed straight after.
+func (ffiObject *FfiObject) clonePointer(debugName string) unsafe.Pointer {
+	pointer := ffiObject.incrementPointer(debugName)
+	ffiObject.decrementPointer()
+	return pointer
+}
+
 func (ffiObject *FfiObject) decrementPointer() {
 	if ffiObject.callCounter.Add(-1) == -1 {
 		ffiObject.freeRustArcPtr()
 	}
 }
 
-func (ffiObject *FfiObject) destroy() {
+// destroy reports whether this call destroyed the object, as opposed to an
+// earlier one.
+func (ffiObject *FfiObject) destroy() bool {
 	if ffiObject.destroyed.CompareAndSwap(false, true) {
 		if ffiObject.callCounter.Add(-1) == -1 {
 			ffiObject.freeRustArcPtr()
 		}
+		return true
 	}
+	return false
 }
 
 func (ffiObject *FfiObject) freeRustArcPtr() {
@@ -806,9 +655,10 @@ type WalletInterface interface {
 }
 type Wallet struct {
 	ffiObject FfiObject
+	state     *walletState
 }
 
-func (_self *Wallet) ArkInfo() (ArkInfo, error) {
+func (_self *Wallet) arkInfoNative() (ArkInfo, error) {
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_uniffiRV, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) RustBufferI {
@@ -825,7 +675,10 @@ func (_self *Wallet) ArkInfo() (ArkInfo, error) {
 	}
 }
 
-func (_self *Wallet) BoardAll() error {
+func (_self *Wallet) boardAllNative() error {
+	if err := _self.beforeAsp(); err != nil {
+		return err
+	}
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) bool {
@@ -836,7 +689,11 @@ func (_self *Wallet) BoardAll() error {
 	return _uniffiErr.AsError()
 }
 
-func (_self *Wallet) Bolt11Invoice(amountSats uint64) (Bolt11Invoice, error) {
+func (_self *Wallet) bolt11InvoiceNative(amountSats uint64) (Bolt11Invoice, error) {
+	if err := _self.beforeAsp(); err != nil {
+		var _uniffiDefaultValue Bolt11Invoice
+		return _uniffiDefaultValue, err
+	}
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_uniffiRV, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) RustBufferI {
@@ -853,7 +710,10 @@ func (_self *Wallet) Bolt11Invoice(amountSats uint64) (Bolt11Invoice, error) {
 	}
 }
 
-func (_self *Wallet) ClaimBolt11Payment(invoice Bolt11Invoice) error {
+func (_self *Wallet) claimBolt11PaymentNative(invoice Bolt11Invoice) error {
+	if err := _self.beforeAsp(); err != nil {
+		return err
+	}
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) bool {
@@ -864,7 +724,7 @@ func (_self *Wallet) ClaimBolt11Payment(invoice Bolt11Invoice) error {
 	return _uniffiErr.AsError()
 }
 
-func (_self *Wallet) ExitAll() error {
+func (_self *Wallet) exitAllNative() error {
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) bool {
@@ -875,7 +735,7 @@ func (_self *Wallet) ExitAll() error {
 	return _uniffiErr.AsError()
 }
 
-func (_self *Wallet) ExitStatus() (ExitStatus, error) {
+func (_self *Wallet) exitStatusNative() (ExitStatus, error) {
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_uniffiRV, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) RustBufferI {
@@ -892,7 +752,7 @@ func (_self *Wallet) ExitStatus() (ExitStatus, error) {
 	}
 }
 
-func (_self *Wallet) LookupInvoice(paymentHash PaymentHash) (*LightningReceive, error) {
+func (_self *Wallet) lookupInvoiceNative(paymentHash PaymentHash) (*LightningReceive, error) {
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_uniffiRV, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) RustBufferI {
@@ -909,7 +769,10 @@ func (_self *Wallet) LookupInvoice(paymentHash PaymentHash) (*LightningReceive,
 	}
 }
 
-func (_self *Wallet) Maintenance() error {
+func (_self *Wallet) maintenanceNative() error {
+	if err := _self.beforeAsp(); err != nil {
+		return err
+	}
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) bool {
@@ -920,7 +783,7 @@ func (_self *Wallet) Maintenance() error {
 	return _uniffiErr.AsError()
 }
 
-func (_self *Wallet) Movements() ([]Movement, error) {
+func (_self *Wallet) movementsNative() ([]Movement, error) {
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_uniffiRV, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) RustBufferI {
@@ -937,7 +800,24 @@ func (_self *Wallet) Movements() ([]Movement, error) {
 	}
 }
 
-func (_self *Wallet) NewAddress() (BarkAddress, error) {
+// movementsBufferNative is movementsNative without the lift: the caller
+// decodes the buffer and must free it.
+func (_self *Wallet) movementsBufferNative() (RustBufferI, error) {
+	_pointer := _self.ffiObject.incrementPointer("*Wallet")
+	defer _self.ffiObject.decrementPointer()
+	_uniffiRV, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) RustBufferI {
+		return GoRustBuffer{
+			inner: C.uniffi_bark_fn_method_wallet_movements(
+				_pointer, _uniffiStatus),
+		}
+	})
+	if _uniffiErr != nil {
+		return nil, _uniffiErr
+	}
+	return _uniffiRV, nil
+}
+
+func (_self *Wallet) newAddressNative() (BarkAddress, error) {
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_uniffiRV, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) RustBufferI {
@@ -954,7 +834,10 @@ func (_self *Wallet) NewAddress() (BarkAddress, error) {
 	}
 }
 
-func (_self *Wallet) OffboardAll() error {
+func (_self *Wallet) offboardAllNative() error {
+	if err := _self.beforeAsp(); err != nil {
+		return err
+	}
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) bool {
@@ -965,7 +848,7 @@ func (_self *Wallet) OffboardAll() error {
 	return _uniffiErr.AsError()
 }
 
-func (_self *Wallet) OnchainAddress() (string, error) {
+func (_self *Wallet) onchainAddressNative() (string, error) {
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_uniffiRV, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) RustBufferI {
@@ -982,7 +865,7 @@ func (_self *Wallet) OnchainAddress() (string, error) {
 	}
 }
 
-func (_self *Wallet) OnchainBalance() (OnchainBalance, error) {
+func (_self *Wallet) onchainBalanceNative() (OnchainBalance, error) {
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_uniffiRV, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) RustBufferI {
@@ -999,7 +882,7 @@ func (_self *Wallet) OnchainBalance() (OnchainBalance, error) {
 	}
 }
 
-func (_self *Wallet) OnchainTransactions() []OnchainTransaction {
+func (_self *Wallet) onchainTransactionsNative() []OnchainTransaction {
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	return FfiConverterSequenceOnchainTransactionINSTANCE.Lift(rustCall(func(_uniffiStatus *C.RustCallStatus) RustBufferI {
@@ -1010,7 +893,7 @@ func (_self *Wallet) OnchainTransactions() []OnchainTransaction {
 	}))
 }
 
-func (_self *Wallet) PayBolt11(invoice Bolt11Invoice, amountSats *uint64) (string, error) {
+func (_self *Wallet) payBolt11Native(invoice Bolt11Invoice, amountSats *uint64) (string, error) {
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_uniffiRV, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) RustBufferI {
@@ -1027,7 +910,10 @@ func (_self *Wallet) PayBolt11(invoice Bolt11Invoice, amountSats *uint64) (strin
 	}
 }
 
-func (_self *Wallet) RefreshAll() error {
+func (_self *Wallet) refreshAllNative() error {
+	if err := _self.beforeAsp(); err != nil {
+		return err
+	}
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) bool {
@@ -1038,7 +924,11 @@ func (_self *Wallet) RefreshAll() error {
 	return _uniffiErr.AsError()
 }
 
-func (_self *Wallet) Send(destination BarkAddress, amountSats uint64) ([]Vtxo, error) {
+func (_self *Wallet) sendNative(destination BarkAddress, amountSats uint64) ([]Vtxo, error) {
+	if err := _self.beforeAsp(); err != nil {
+		var _uniffiDefaultValue []Vtxo
+		return _uniffiDefaultValue, err
+	}
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_uniffiRV, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) RustBufferI {
@@ -1055,7 +945,11 @@ func (_self *Wallet) Send(destination BarkAddress, amountSats uint64) ([]Vtxo, e
 	}
 }
 
-func (_self *Wallet) SendOnchain(address string, amountSats uint64) (string, error) {
+func (_self *Wallet) sendOnchainNative(address string, amountSats uint64) (string, error) {
+	if err := _self.beforeAsp(); err != nil {
+		var _uniffiDefaultValue string
+		return _uniffiDefaultValue, err
+	}
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_uniffiRV, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) RustBufferI {
@@ -1072,7 +966,10 @@ func (_self *Wallet) SendOnchain(address string, amountSats uint64) (string, err
 	}
 }
 
-func (_self *Wallet) Sync() error {
+func (_self *Wallet) syncNative() error {
+	if _self.state != nil {
+		_self.state.aspLimiter.wait()
+	}
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) bool {
@@ -1083,7 +980,7 @@ func (_self *Wallet) Sync() error {
 	return _uniffiErr.AsError()
 }
 
-func (_self *Wallet) Utxos() []Utxo {
+func (_self *Wallet) utxosNative() []Utxo {
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	return FfiConverterSequenceUtxoINSTANCE.Lift(rustCall(func(_uniffiStatus *C.RustCallStatus) RustBufferI {
@@ -1094,7 +991,7 @@ func (_self *Wallet) Utxos() []Utxo {
 	}))
 }
 
-func (_self *Wallet) Vtxos() ([]Vtxo, error) {
+func (_self *Wallet) vtxosNative() ([]Vtxo, error) {
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_uniffiRV, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) RustBufferI {
@@ -1111,7 +1008,7 @@ func (_self *Wallet) Vtxos() ([]Vtxo, error) {
 	}
 }
 
-func (_self *Wallet) WalletBalance() (WalletBalance, error) {
+func (_self *Wallet) walletBalanceNative() (WalletBalance, error) {
 	_pointer := _self.ffiObject.incrementPointer("*Wallet")
 	defer _self.ffiObject.decrementPointer()
 	_uniffiRV, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) RustBufferI {
@@ -1129,7 +1026,9 @@ func (_self *Wallet) WalletBalance() (WalletBalance, error) {
 }
 func (object *Wallet) Destroy() {
 	runtime.SetFinalizer(object, nil)
-	object.ffiObject.destroy()
+	if object.ffiObject.destroy() {
+		object.state.release()
+	}
 }
 
 type FfiConverterWallet struct{}
@@ -1138,7 +1037,7 @@ var FfiConverterWalletINSTANCE = FfiConverterWallet{}
 
 func (c FfiConverterWallet) Lift(pointer unsafe.Pointer) *Wallet {
 	result := &Wallet{
-		newFfiObject(
+		ffiObject: newFfiObject(
 			pointer,
 			func(pointer unsafe.Pointer, status *C.RustCallStatus) unsafe.Pointer {
 				return C.uniffi_bark_fn_clone_wallet(pointer, status)
@@ -1147,6 +1046,7 @@ func (c FfiConverterWallet) Lift(pointer unsafe.Pointer) *Wallet {
 				C.uniffi_bark_fn_free_wallet(pointer, status)
 			},
 		),
+		state: &walletState{},
 	}
 	runtime.SetFinalizer(result, (*Wallet).Destroy)
 	return result
@@ -1157,13 +1057,7 @@ func (c FfiConverterWallet) Read(reader io.Reader) *Wallet {
 }
 
 func (c FfiConverterWallet) Lower(value *Wallet) unsafe.Pointer {
-	// TODO: this is bad - all synchronization from ObjectRuntime.go is discarded here,
-	// because the pointer will be decremented immediately after this function returns,
-	// and someone will be left holding onto a non-locked pointer.
-	pointer := value.ffiObject.incrementPointer("*Wallet")
-	defer value.ffiObject.decrementPointer()
-	return pointer
-
+	return value.ffiObject.clonePointer("*Wallet")
 }
 
 func (c FfiConverterWallet) Write(writer io.Writer, value *Wallet) {
@@ -1240,6 +1134,19 @@ type Config struct {
 	Network        Network
 	AspAddress     string
 	EsploraAddress string
+
+	// Fields below are applied by the Go layer and are not sent to the
+	// native library.
+
+	// DbBusyTimeoutMs is how long CreateWallet and OpenWallet keep retrying
+	// while the database is locked by someone else. Defaults to
+	// DefaultDbBusyTimeoutMs.
+	DbBusyTimeoutMs *uint32
+	// DataDir is where the Go layer keeps caches, separate from the
+	// database at the wallet path. When nil caches are kept in memory only.
+	// CreateWallet creates the directory if needed and checks it is
+	// writable.
+	DataDir *string
 }
 
 func (r *Config) Destroy() {
@@ -1258,9 +1165,9 @@ func (c FfiConverterConfig) Lift(rb RustBufferI) Config {
 
 func (c FfiConverterConfig) Read(reader io.Reader) Config {
 	return Config{
-		FfiConverterTypeNetworkINSTANCE.Read(reader),
-		FfiConverterStringINSTANCE.Read(reader),
-		FfiConverterStringINSTANCE.Read(reader),
+		Network:        FfiConverterTypeNetworkINSTANCE.Read(reader),
+		AspAddress:     FfiConverterStringINSTANCE.Read(reader),
+		EsploraAddress: FfiConverterStringINSTANCE.Read(reader),
 	}
 }
 
@@ -1375,6 +1282,15 @@ type Movement struct {
 	AmountReceivedSat uint64
 	FeesSat           uint64
 	CreatedAt         string
+
+	// Fields below are kept by the Go layer and are not part of the native
+	// movement.
+
+	// Rate is the fiat rate recorded with SetMovementRate, if any.
+	Rate *MovementRate
+	// Imported is set on movements added with ImportMovements. Their ids
+	// are their own and may repeat those of native movements.
+	Imported bool
 }
 
 func (r *Movement) Destroy() {
@@ -1396,12 +1312,12 @@ func (c FfiConverterMovement) Lift(rb RustBufferI) Movement {
 
 func (c FfiConverterMovement) Read(reader io.Reader) Movement {
 	return Movement{
-		FfiConverterUint32INSTANCE.Read(reader),
-		FfiConverterMovementKindINSTANCE.Read(reader),
-		FfiConverterUint64INSTANCE.Read(reader),
-		FfiConverterUint64INSTANCE.Read(reader),
-		FfiConverterUint64INSTANCE.Read(reader),
-		FfiConverterStringINSTANCE.Read(reader),
+		Id:                FfiConverterUint32INSTANCE.Read(reader),
+		Kind:              FfiConverterMovementKindINSTANCE.Read(reader),
+		AmountSentSat:     FfiConverterUint64INSTANCE.Read(reader),
+		AmountReceivedSat: FfiConverterUint64INSTANCE.Read(reader),
+		FeesSat:           FfiConverterUint64INSTANCE.Read(reader),
+		CreatedAt:         FfiConverterStringINSTANCE.Read(reader),
 	}
 }
 
@@ -1960,25 +1876,25 @@ type FfiDestroyerError struct{}
 
 func (_ FfiDestroyerError) Destroy(value *Error) {
 	switch variantValue := value.err.(type) {
-	case ErrorBarkDbFileNotAccessible:
+	case *ErrorBarkDbFileNotAccessible:
 		variantValue.destroy()
-	case ErrorBarkDbFileAlreadyExists:
+	case *ErrorBarkDbFileAlreadyExists:
 		variantValue.destroy()
-	case ErrorInvalidNetwork:
+	case *ErrorInvalidNetwork:
 		variantValue.destroy()
-	case ErrorInvalidPublicKey:
+	case *ErrorInvalidPublicKey:
 		variantValue.destroy()
-	case ErrorInvalidMnemonic:
+	case *ErrorInvalidMnemonic:
 		variantValue.destroy()
-	case ErrorInvalidBolt11Invoice:
+	case *ErrorInvalidBolt11Invoice:
 		variantValue.destroy()
-	case ErrorInvalidBitcoinAddress:
+	case *ErrorInvalidBitcoinAddress:
 		variantValue.destroy()
-	case ErrorInvalidBarkAddress:
+	case *ErrorInvalidBarkAddress:
 		variantValue.destroy()
-	case ErrorInvalidPaymentHash:
+	case *ErrorInvalidPaymentHash:
 		variantValue.destroy()
-	case ErrorBarkFailed:
+	case *ErrorBarkFailed:
 		variantValue.destroy()
 	default:
 		_ = variantValue
@@ -2027,6 +1943,8 @@ func (_ FfiDestroyerMovementKind) Destroy(value MovementKind) {
 
 type Utxo interface {
 	Destroy()
+	Kind() UtxoKind
+	ValueSat() uint64
 }
 type UtxoLocal struct {
 	Outpoint           OutPoint
@@ -2224,9 +2142,6 @@ func (c FfiConverterSequenceMovement) Lift(rb RustBufferI) []Movement {
 
 func (c FfiConverterSequenceMovement) Read(reader io.Reader) []Movement {
 	length := readInt32(reader)
-	if length == 0 {
-		return nil
-	}
 	result := make([]Movement, 0, length)
 	for i := int32(0); i < length; i++ {
 		result = append(result, FfiConverterMovementINSTANCE.Read(reader))
@@ -2267,9 +2182,6 @@ func (c FfiConverterSequenceOnchainTransaction) Lift(rb RustBufferI) []OnchainTr
 
 func (c FfiConverterSequenceOnchainTransaction) Read(reader io.Reader) []OnchainTransaction {
 	length := readInt32(reader)
-	if length == 0 {
-		return nil
-	}
 	result := make([]OnchainTransaction, 0, length)
 	for i := int32(0); i < length; i++ {
 		result = append(result, FfiConverterOnchainTransactionINSTANCE.Read(reader))
@@ -2310,9 +2222,6 @@ func (c FfiConverterSequenceVtxo) Lift(rb RustBufferI) []Vtxo {
 
 func (c FfiConverterSequenceVtxo) Read(reader io.Reader) []Vtxo {
 	length := readInt32(reader)
-	if length == 0 {
-		return nil
-	}
 	result := make([]Vtxo, 0, length)
 	for i := int32(0); i < length; i++ {
 		result = append(result, FfiConverterVtxoINSTANCE.Read(reader))
@@ -2353,9 +2262,6 @@ func (c FfiConverterSequenceUtxo) Lift(rb RustBufferI) []Utxo {
 
 func (c FfiConverterSequenceUtxo) Read(reader io.Reader) []Utxo {
 	length := readInt32(reader)
-	if length == 0 {
-		return nil
-	}
 	result := make([]Utxo, 0, length)
 	for i := int32(0); i < length; i++ {
 		result = append(result, FfiConverterUtxoINSTANCE.Read(reader))
@@ -2409,15 +2315,35 @@ type FfiDestroyerTypeBolt11Invoice = FfiDestroyerString
 var FfiConverterTypeBolt11InvoiceINSTANCE = FfiConverterString{}
 
 /**
- * Typealias from the type name used in the UDL file to the builtin type.  This
- * is needed because the UDL type name is used in function/method signatures.
- * It's also what we have an external type that references a custom type.
+ * Network is a distinct type rather than an alias so that only its constants
+ * can be passed without a conversion. It crosses the FFI boundary as the
+ * string the native library expects.
  */
-type Network = string
-type FfiConverterTypeNetwork = FfiConverterString
-type FfiDestroyerTypeNetwork = FfiDestroyerString
+type Network string
+
+type FfiConverterTypeNetwork struct{}
+
+var FfiConverterTypeNetworkINSTANCE = FfiConverterTypeNetwork{}
+
+func (FfiConverterTypeNetwork) Lift(rb RustBufferI) Network {
+	return Network(FfiConverterStringINSTANCE.Lift(rb))
+}
+
+func (FfiConverterTypeNetwork) Read(reader io.Reader) Network {
+	return Network(FfiConverterStringINSTANCE.Read(reader))
+}
+
+func (FfiConverterTypeNetwork) Lower(value Network) C.RustBuffer {
+	return FfiConverterStringINSTANCE.Lower(string(value))
+}
+
+func (FfiConverterTypeNetwork) Write(writer io.Writer, value Network) {
+	FfiConverterStringINSTANCE.Write(writer, string(value))
+}
+
+type FfiDestroyerTypeNetwork struct{}
 
-var FfiConverterTypeNetworkINSTANCE = FfiConverterString{}
+func (FfiDestroyerTypeNetwork) Destroy(_ Network) {}
 
 /**
  * Typealias from the type name used in the UDL file to the builtin type.  This
@@ -2441,7 +2367,7 @@ type FfiDestroyerTypePublicKey = FfiDestroyerString
 
 var FfiConverterTypePublicKeyINSTANCE = FfiConverterString{}
 
-func CreateWallet(path string, mnemonic string, config Config) (*Wallet, error) {
+func createWalletNative(path string, mnemonic string, config Config) (*Wallet, error) {
 	_uniffiRV, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) unsafe.Pointer {
 		return C.uniffi_bark_fn_func_create_wallet(FfiConverterStringINSTANCE.Lower(path), FfiConverterStringINSTANCE.Lower(mnemonic), FfiConverterConfigINSTANCE.Lower(config), _uniffiStatus)
 	})
@@ -2453,7 +2379,7 @@ func CreateWallet(path string, mnemonic string, config Config) (*Wallet, error)
 	}
 }
 
-func OpenWallet(path string, mnemonic string) (*Wallet, error) {
+func openWalletNative(path string, mnemonic string) (*Wallet, error) {
 	_uniffiRV, _uniffiErr := rustCallWithError[Error](FfiConverterError{}, func(_uniffiStatus *C.RustCallStatus) unsafe.Pointer {
 		return C.uniffi_bark_fn_func_open_wallet(FfiConverterStringINSTANCE.Lower(path), FfiConverterStringINSTANCE.Lower(mnemonic), _uniffiStatus)
 	})
@@ -0,0 +1,113 @@
+package bark
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// bech32Variant tells bech32 (BIP173) and bech32m (BIP350) checksums apart.
+type bech32Variant uint
+
+const (
+	bech32Plain bech32Variant = iota + 1
+	bech32M
+)
+
+var errBech32 = errors.New("invalid bech32 string")
+
+// bech32Decode splits a bech32 or bech32m string into its human-readable
+// part and 5-bit data, without the checksum. It does not enforce the
+// 90 character limit, which LNURLs exceed.
+func bech32Decode(s string) (hrp string, data []byte, variant bech32Variant, err error) {
+	if s != strings.ToLower(s) && s != strings.ToUpper(s) {
+		return "", nil, 0, fmt.Errorf("%w: mixed case", errBech32)
+	}
+	s = strings.ToLower(s)
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+7 > len(s) {
+		return "", nil, 0, fmt.Errorf("%w: bad separator position", errBech32)
+	}
+	hrp = s[:sep]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, 0, fmt.Errorf("%w: bad character in prefix", errBech32)
+		}
+	}
+	data = make([]byte, 0, len(s)-sep-1)
+	for _, c := range s[sep+1:] {
+		v := strings.IndexRune(bech32Charset, c)
+		if v < 0 {
+			return "", nil, 0, fmt.Errorf("%w: bad character %q", errBech32, c)
+		}
+		data = append(data, byte(v))
+	}
+	switch bech32Polymod(append(bech32ExpandHrp(hrp), data...)) {
+	case 1:
+		variant = bech32Plain
+	case 0x2bc830a3:
+		variant = bech32M
+	default:
+		return "", nil, 0, fmt.Errorf("%w: bad checksum", errBech32)
+	}
+	return hrp, data[:len(data)-6], variant, nil
+}
+
+func bech32Polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32ExpandHrp(hrp string) []byte {
+	out := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+// convertBits regroups data from fromBits-bit to toBits-bit values. With pad
+// unset, leftover bits must be zero padding, as when decoding.
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var (
+		acc  uint32
+		bits uint
+		out  []byte
+	)
+	maxv := uint32(1)<<toBits - 1
+	for _, v := range data {
+		if uint32(v)>>fromBits != 0 {
+			return nil, fmt.Errorf("%w: value out of range", errBech32)
+		}
+		acc = acc<<fromBits | uint32(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(toBits-bits)&maxv))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxv != 0 {
+		return nil, fmt.Errorf("%w: bad padding", errBech32)
+	}
+	return out, nil
+}
@@ -0,0 +1,73 @@
+package bark
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+// secp256k1N is the order of the secp256k1 group.
+var secp256k1N, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+
+var errInvalidExtendedKey = errors.New("derived key is invalid")
+
+// extendedKey is a BIP32 extended private key. Only hardened derivation is
+// supported, which needs no elliptic curve arithmetic.
+type extendedKey struct {
+	key       [32]byte
+	chainCode [32]byte
+}
+
+func masterKey(seed []byte) (extendedKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	k := new(big.Int).SetBytes(sum[:32])
+	if k.Sign() == 0 || k.Cmp(secp256k1N) >= 0 {
+		return extendedKey{}, errInvalidExtendedKey
+	}
+	var master extendedKey
+	copy(master.key[:], sum[:32])
+	copy(master.chainCode[:], sum[32:])
+	return master, nil
+}
+
+// hardenedChild derives the hardened child at index, which must be below
+// 2^31; the hardened bit is added here.
+func (k extendedKey) hardenedChild(index uint32) (extendedKey, error) {
+	var data [37]byte
+	copy(data[1:33], k.key[:])
+	binary.BigEndian.PutUint32(data[33:], index|1<<31)
+
+	mac := hmac.New(sha512.New, k.chainCode[:])
+	mac.Write(data[:])
+	sum := mac.Sum(nil)
+
+	tweak := new(big.Int).SetBytes(sum[:32])
+	if tweak.Cmp(secp256k1N) >= 0 {
+		return extendedKey{}, errInvalidExtendedKey
+	}
+	child := tweak.Add(tweak, new(big.Int).SetBytes(k.key[:]))
+	child.Mod(child, secp256k1N)
+	if child.Sign() == 0 {
+		return extendedKey{}, errInvalidExtendedKey
+	}
+
+	var result extendedKey
+	child.FillBytes(result.key[:])
+	copy(result.chainCode[:], sum[32:])
+	return result, nil
+}
+
+func (k extendedKey) derivePath(path ...uint32) (extendedKey, error) {
+	var err error
+	for _, index := range path {
+		if k, err = k.hardenedChild(index); err != nil {
+			return extendedKey{}, err
+		}
+	}
+	return k, nil
+}
@@ -0,0 +1,95 @@
+package bark
+
+import (
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"fmt"
+	"strings"
+)
+
+//go:embed bip39_english.txt
+var bip39EnglishList string
+
+// bip39English is the BIP39 English wordlist and bip39Index maps each word
+// to its position in it.
+var (
+	bip39English = strings.Fields(bip39EnglishList)
+	bip39Index   = func() map[string]int {
+		index := make(map[string]int, len(bip39English))
+		for i, word := range bip39English {
+			index[word] = i
+		}
+		return index
+	}()
+)
+
+// entropyToMnemonic encodes 16 to 32 bytes of entropy, in steps of four, as
+// a BIP39 English mnemonic.
+func entropyToMnemonic(entropy []byte) (string, error) {
+	if len(entropy) < 16 || len(entropy) > 32 || len(entropy)%4 != 0 {
+		return "", fmt.Errorf("invalid entropy length %d", len(entropy))
+	}
+	checksumBits := len(entropy) / 4
+	hash := sha256.Sum256(entropy)
+	bits := append(append([]byte{}, entropy...), hash[0])
+
+	wordCount := (len(entropy)*8 + checksumBits) / 11
+	words := make([]string, wordCount)
+	for i := range words {
+		var index int
+		for j := 0; j < 11; j++ {
+			bit := i*11 + j
+			index = index<<1 | int(bits[bit/8]>>(7-bit%8)&1)
+		}
+		words[i] = bip39English[index]
+	}
+	return strings.Join(words, " "), nil
+}
+
+// mnemonicToEntropy decodes a BIP39 English mnemonic, checking its length,
+// words and checksum.
+func mnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return nil, fmt.Errorf("%w: expected 12, 15, 18, 21 or 24 words, got %d", ErrErrorInvalidMnemonic, len(words))
+	}
+
+	totalBits := len(words) * 11
+	checksumBits := totalBits / 33
+	bits := make([]byte, (totalBits+7)/8)
+	for i, word := range words {
+		index, ok := bip39Index[word]
+		if !ok {
+			return nil, fmt.Errorf("%w: word %d (%q) is not in the wordlist", ErrErrorInvalidMnemonic, i+1, word)
+		}
+		for j := 0; j < 11; j++ {
+			if index>>(10-j)&1 == 1 {
+				bit := i*11 + j
+				bits[bit/8] |= 1 << (7 - bit%8)
+			}
+		}
+	}
+
+	entropy := bits[:(totalBits-checksumBits)/8]
+	hash := sha256.Sum256(entropy)
+	want := hash[0] >> (8 - checksumBits)
+	got := bits[len(entropy)] >> (8 - checksumBits)
+	if want != got {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrErrorInvalidMnemonic)
+	}
+	return entropy, nil
+}
+
+// mnemonicToSeed derives the BIP39 seed of a mnemonic with an empty
+// passphrase.
+func mnemonicToSeed(mnemonic string) ([]byte, error) {
+	if _, err := mnemonicToEntropy(mnemonic); err != nil {
+		return nil, err
+	}
+	normalized := strings.Join(strings.Fields(strings.ToLower(mnemonic)), " ")
+	return pbkdf2.Key(sha512.New, normalized, []byte("mnemonic"), 2048, 64)
+}
@@ -0,0 +1,108 @@
+package bark
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// bolt11AmountMsat returns the amount encoded in the human-readable part of
+// a BOLT11 invoice, in millisatoshis. ok is false if the invoice does not
+// specify an amount.
+func bolt11AmountMsat(invoice Bolt11Invoice) (msat uint64, ok bool, err error) {
+	invoice = strings.ToLower(strings.TrimSpace(invoice))
+	invoice = strings.TrimPrefix(invoice, "lightning:")
+	sep := strings.LastIndexByte(invoice, '1')
+	if !strings.HasPrefix(invoice, "ln") || sep < 0 {
+		return 0, false, fmt.Errorf("%w: missing ln prefix or separator", ErrErrorInvalidBolt11Invoice)
+	}
+	hrp := invoice[2:sep]
+
+	// The currency prefix is letters only, the amount follows it.
+	i := 0
+	for i < len(hrp) && hrp[i] >= 'a' && hrp[i] <= 'z' {
+		i++
+	}
+	amount := hrp[i:]
+	if amount == "" {
+		return 0, false, nil
+	}
+
+	multiplier := amount[len(amount)-1]
+	if multiplier >= '0' && multiplier <= '9' {
+		multiplier = 0
+	} else {
+		amount = amount[:len(amount)-1]
+	}
+	n, err := strconv.ParseUint(amount, 10, 64)
+	if err != nil || amount == "" || (amount[0] == '0' && len(amount) > 1) {
+		return 0, false, fmt.Errorf("%w: bad amount %q", ErrErrorInvalidBolt11Invoice, hrp[i:])
+	}
+
+	// Millisatoshis per unit of each multiplier; pico-bitcoin is a tenth of
+	// a millisatoshi and must come in multiples of ten.
+	var perUnit uint64
+	switch multiplier {
+	case 0:
+		perUnit = 100_000_000_000
+	case 'm':
+		perUnit = 100_000_000
+	case 'u':
+		perUnit = 100_000
+	case 'n':
+		perUnit = 100
+	case 'p':
+		if n%10 != 0 {
+			return 0, false, fmt.Errorf("%w: sub-millisatoshi amount %q", ErrErrorInvalidBolt11Invoice, hrp[i:])
+		}
+		return n / 10, true, nil
+	default:
+		return 0, false, fmt.Errorf("%w: bad amount multiplier %q", ErrErrorInvalidBolt11Invoice, multiplier)
+	}
+	if n > math.MaxUint64/perUnit {
+		return 0, false, fmt.Errorf("%w: amount overflows", ErrErrorInvalidBolt11Invoice)
+	}
+	return n * perUnit, true, nil
+}
+
+// bolt11SignatureGroups is the length, in 5-bit groups, of the signature
+// that ends every BOLT11 invoice.
+const bolt11SignatureGroups = 104
+
+// bolt11PaymentHash returns the hex payment hash of a BOLT11 invoice, read
+// from its p field.
+func bolt11PaymentHash(invoice Bolt11Invoice) (PaymentHash, error) {
+	invoice = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(invoice)), "lightning:")
+	_, data, _, err := bech32Decode(invoice)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrErrorInvalidBolt11Invoice, err)
+	}
+	// Skip the 35-bit timestamp and stop before the signature.
+	if len(data) < 7+bolt11SignatureGroups {
+		return "", fmt.Errorf("%w: too short", ErrErrorInvalidBolt11Invoice)
+	}
+	fields := data[7 : len(data)-bolt11SignatureGroups]
+	for len(fields) >= 3 {
+		tag := fields[0]
+		length := int(fields[1])<<5 | int(fields[2])
+		if len(fields) < 3+length {
+			return "", fmt.Errorf("%w: truncated field", ErrErrorInvalidBolt11Invoice)
+		}
+		value := fields[3 : 3+length]
+		fields = fields[3+length:]
+		// Tag 1 is p; a payment hash field of any other length must be
+		// skipped.
+		if tag != 1 || length != 52 {
+			continue
+		}
+		// 52 groups are 260 bits: the hash and four bits of padding.
+		hash, err := convertBits(value, 5, 8, false)
+		if err != nil || len(hash) != 32 {
+			return "", fmt.Errorf("%w: bad payment hash", ErrErrorInvalidBolt11Invoice)
+		}
+		return hex.EncodeToString(hash), nil
+	}
+	return "", fmt.Errorf("%w: no payment hash", ErrErrorInvalidBolt11Invoice)
+}
@@ -0,0 +1,73 @@
+package bark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SendBounds returns the smallest and largest amount, in sats, that can be
+// sent to destination right now. destination may be an Ark address, an
+// onchain address, a BOLT11 invoice, an LNURL or a lightning address; LNURLs
+// and lightning addresses are resolved over HTTP to read their limits.
+//
+// The upper bound never exceeds the spendable balance, and for Ark
+// addresses the ASP's maximum vtxo size. It fails with
+// ErrErrorInsufficientFunds if no amount can be sent.
+func (_self *Wallet) SendBounds(destination string) (minSat, maxSat uint64, err error) {
+	destination = normalizeDestination(strings.TrimPrefix(strings.TrimSpace(destination), "lightning:"))
+	balance, err := _self.WalletBalance()
+	if err != nil {
+		return 0, 0, err
+	}
+	minSat, maxSat = DustLimitSat, balance.SpendableSat
+
+	switch {
+	case strings.HasPrefix(destination, "lnurl") || strings.Contains(destination, "@"):
+		ctx, cancel := context.WithTimeout(context.Background(), esploraTimeout)
+		defer cancel()
+		params, err := fetchLnurlPay(ctx, destination)
+		if err != nil {
+			return 0, 0, err
+		}
+		minSat = (params.MinSendable + 999) / 1000
+		maxSat = min(params.MaxSendable/1000, maxSat)
+	case strings.HasPrefix(destination, "ln"):
+		msat, ok, err := bolt11AmountMsat(destination)
+		if err != nil {
+			return 0, 0, err
+		}
+		minSat = 1
+		if ok {
+			amount := (msat + 999) / 1000
+			if amount > maxSat {
+				return 0, 0, fmt.Errorf("%w: invoice is for %d sat, %d sat spendable",
+					ErrErrorInsufficientFunds, amount, maxSat)
+			}
+			minSat, maxSat = amount, amount
+		}
+	case isArkAddress(destination):
+		info, err := _self.ArkInfo()
+		if err != nil {
+			return 0, 0, err
+		}
+		if info.MaxVtxoAmountSats != nil {
+			maxSat = min(*info.MaxVtxoAmountSats, maxSat)
+		}
+	default:
+		// Anything else goes onchain; SendOnchain validates the address.
+	}
+
+	if minSat > maxSat {
+		return 0, 0, fmt.Errorf("%w: at least %d sat needed, at most %d sat can be sent",
+			ErrErrorInsufficientFunds, minSat, maxSat)
+	}
+	return minSat, maxSat, nil
+}
+
+// isArkAddress reports whether address has the human-readable part of an
+// Ark address on mainnet or a test network.
+func isArkAddress(address string) bool {
+	hrp, _, _, err := bech32Decode(address)
+	return err == nil && (hrp == "ark" || hrp == "tark")
+}
@@ -0,0 +1,33 @@
+package bark
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// minTxSize is the size in bytes of the smallest transaction Bitcoin Core
+// will relay.
+const minTxSize = 65
+
+// BroadcastTransaction submits a raw transaction, given as hex, through the
+// wallet's Esplora endpoint and returns its txid. If the node rejects the
+// transaction the returned error wraps ErrErrorEsploraFailed and carries the
+// node's reason.
+func (_self *Wallet) BroadcastTransaction(txHex string) (string, error) {
+	txHex = strings.TrimSpace(txHex)
+	raw, err := hex.DecodeString(txHex)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrErrorInvalidTransaction, err)
+	}
+	if len(raw) < minTxSize {
+		return "", fmt.Errorf("%w: %d bytes is too short", ErrErrorInvalidTransaction, len(raw))
+	}
+
+	esplora, err := _self.esplora()
+	if err != nil {
+		return "", err
+	}
+	return esplora.broadcast(context.Background(), strings.ToLower(txHex))
+}
@@ -0,0 +1,102 @@
+package bark
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// CompatibilityCheckEnv names the environment variable that controls what
+// happens when the loaded native library does not match the bindings. By
+// default the package panics while initializing. Set it to "soft" to carry
+// on instead: the mismatch is reported to the Logger once one is set with
+// SetLogger, and stays available from CheckCompatibility.
+const CompatibilityCheckEnv = "BARK_COMPATIBILITY_CHECK"
+
+// ChecksumMismatch is an API checksum of the native library that differs
+// from the one the bindings were generated against.
+type ChecksumMismatch struct {
+	Symbol string
+	Want   uint16
+	Got    uint16
+}
+
+// CompatibilityError lists how the native library differs from the
+// bindings. If the contract versions differ the checksums are not compared,
+// since the library's symbols cannot be trusted, and Checksums is empty.
+type CompatibilityError struct {
+	WantContractVersion uint32
+	GotContractVersion  uint32
+	Checksums           []ChecksumMismatch
+}
+
+func (err *CompatibilityError) Error() string {
+	if err.WantContractVersion != err.GotContractVersion {
+		return fmt.Sprintf("bark: UniFFI contract version mismatch: bindings %d, library %d",
+			err.WantContractVersion, err.GotContractVersion)
+	}
+	symbols := make([]string, 0, len(err.Checksums))
+	for _, mismatch := range err.Checksums {
+		symbols = append(symbols, fmt.Sprintf("%s (want %d, got %d)", mismatch.Symbol, mismatch.Want, mismatch.Got))
+	}
+	return "bark: UniFFI API checksum mismatch: " + strings.Join(symbols, ", ")
+}
+
+func (err *CompatibilityError) Is(target error) bool {
+	return target == ErrErrorIncompatibleLibrary
+}
+
+// CheckCompatibility checks that the loaded native library matches the
+// bindings, and returns a *CompatibilityError describing every difference
+// if it does not. If the contract versions differ, rebuild the library.
+func CheckCompatibility() error {
+	return checkCompatibility(uniffiScaffoldingContractVersion(), uniffiChecksums)
+}
+
+func checkCompatibility(contractVersion uint32, checksums []uniffiChecksum) error {
+	if contractVersion != uniffiBindingsContractVersion {
+		return &CompatibilityError{
+			WantContractVersion: uniffiBindingsContractVersion,
+			GotContractVersion:  contractVersion,
+		}
+	}
+	var mismatches []ChecksumMismatch
+	for _, checksum := range checksums {
+		if got := checksum.checksum(); got != checksum.want {
+			mismatches = append(mismatches, ChecksumMismatch{Symbol: checksum.symbol, Want: checksum.want, Got: got})
+		}
+	}
+	if len(mismatches) == 0 {
+		return nil
+	}
+	return &CompatibilityError{
+		WantContractVersion: uniffiBindingsContractVersion,
+		GotContractVersion:  contractVersion,
+		Checksums:           mismatches,
+	}
+}
+
+// compatibilityErr is the mismatch found at initialization in soft mode,
+// until it has been reported to a Logger.
+var compatibilityErr atomic.Pointer[error]
+
+func uniffiCheckChecksums() {
+	err := CheckCompatibility()
+	if err == nil {
+		return
+	}
+	if os.Getenv(CompatibilityCheckEnv) != "soft" {
+		// If this happens try cleaning and rebuilding your project
+		panic(err)
+	}
+	compatibilityErr.Store(&err)
+}
+
+// reportCompatibility logs the mismatch found at initialization, if any,
+// to l.
+func reportCompatibility(l Logger) {
+	if err := compatibilityErr.Swap(nil); err != nil {
+		l.Errorf("%v", *err)
+	}
+}
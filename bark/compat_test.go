@@ -0,0 +1,91 @@
+package bark
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func fixedChecksum(value uint16) func() uint16 {
+	return func() uint16 { return value }
+}
+
+func TestCheckCompatibilityLoadedLibrary(t *testing.T) {
+	if err := CheckCompatibility(); err != nil {
+		t.Fatalf("CheckCompatibility with the bundled library: %v", err)
+	}
+}
+
+func TestCheckCompatibilityChecksumMismatch(t *testing.T) {
+	checksums := []uniffiChecksum{
+		{"uniffi_bark_checksum_func_create_wallet", fixedChecksum(100), 100},
+		{"uniffi_bark_checksum_method_wallet_send", fixedChecksum(7), 200},
+		{"uniffi_bark_checksum_method_wallet_sync", fixedChecksum(300), 300},
+		{"uniffi_bark_checksum_method_wallet_vtxos", fixedChecksum(9), 400},
+	}
+	err := checkCompatibility(uniffiBindingsContractVersion, checksums)
+	if !errors.Is(err, ErrErrorIncompatibleLibrary) {
+		t.Fatalf("error = %v, want ErrErrorIncompatibleLibrary", err)
+	}
+	var compat *CompatibilityError
+	if !errors.As(err, &compat) {
+		t.Fatalf("error %T is not a *CompatibilityError", err)
+	}
+	want := []ChecksumMismatch{
+		{Symbol: "uniffi_bark_checksum_method_wallet_send", Want: 200, Got: 7},
+		{Symbol: "uniffi_bark_checksum_method_wallet_vtxos", Want: 400, Got: 9},
+	}
+	if !reflect.DeepEqual(compat.Checksums, want) {
+		t.Errorf("Checksums = %+v, want %+v", compat.Checksums, want)
+	}
+	for _, mismatch := range want {
+		if !strings.Contains(err.Error(), mismatch.Symbol) {
+			t.Errorf("error %q does not name %s", err, mismatch.Symbol)
+		}
+	}
+	if strings.Contains(err.Error(), "create_wallet") {
+		t.Errorf("error %q names a matching symbol", err)
+	}
+}
+
+func TestCheckCompatibilityContractVersion(t *testing.T) {
+	checksums := []uniffiChecksum{
+		{"uniffi_bark_checksum_method_wallet_send", func() uint16 {
+			t.Error("checksums compared despite a contract version mismatch")
+			return 0
+		}, 200},
+	}
+	err := checkCompatibility(uniffiBindingsContractVersion+1, checksums)
+	var compat *CompatibilityError
+	if !errors.As(err, &compat) {
+		t.Fatalf("error = %v, want a *CompatibilityError", err)
+	}
+	if compat.GotContractVersion != uniffiBindingsContractVersion+1 || len(compat.Checksums) != 0 {
+		t.Errorf("error = %+v, want only the contract versions", compat)
+	}
+}
+
+func TestCheckCompatibilityMatch(t *testing.T) {
+	checksums := []uniffiChecksum{{"uniffi_bark_checksum_func_create_wallet", fixedChecksum(1), 1}}
+	if err := checkCompatibility(uniffiBindingsContractVersion, checksums); err != nil {
+		t.Fatalf("checkCompatibility = %v, want nil", err)
+	}
+}
+
+func TestReportCompatibility(t *testing.T) {
+	var reported error = &CompatibilityError{
+		WantContractVersion: uniffiBindingsContractVersion,
+		GotContractVersion:  uniffiBindingsContractVersion,
+		Checksums:           []ChecksumMismatch{{Symbol: "uniffi_bark_checksum_method_wallet_send", Want: 1, Got: 2}},
+	}
+	compatibilityErr.Store(&reported)
+	t.Cleanup(func() { compatibilityErr.Store(nil) })
+
+	l := setTestLogger(t)
+	// Setting a logger again does not repeat the report.
+	SetLogger(l)
+	if len(l.errors) != 1 || !strings.Contains(l.errors[0], "wallet_send") {
+		t.Errorf("error lines = %q, want the mismatch reported once", l.errors)
+	}
+}
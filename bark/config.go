@@ -0,0 +1,61 @@
+package bark
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DefaultURLScheme is prepended to Config URLs that have no scheme.
+const DefaultURLScheme = "https"
+
+// Validate checks that the network is one the native library accepts and
+// rewrites the config's URLs into canonical form: a scheme is added if
+// missing, scheme and host are lower-cased and trailing slashes are removed.
+// Errors name the offending field. CreateWallet validates its config, and
+// OpenWallet the config recorded at creation, before touching the database.
+func (c *Config) Validate() error {
+	if _, err := ParseNetwork(string(c.Network)); err != nil {
+		return fmt.Errorf("%w: Network: unknown network %q", ErrErrorInvalidNetwork, c.Network)
+	}
+	aspAddress, err := normalizeURL("AspAddress", c.AspAddress)
+	if err != nil {
+		return err
+	}
+	esploraAddress, err := normalizeURL("EsploraAddress", c.EsploraAddress)
+	if err != nil {
+		return err
+	}
+	c.AspAddress = aspAddress
+	c.EsploraAddress = esploraAddress
+	return nil
+}
+
+func normalizeURL(field string, raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", fmt.Errorf("%w: %s is empty", ErrErrorInvalidURL, field)
+	}
+	if !strings.Contains(raw, "://") {
+		raw = DefaultURLScheme + "://" + raw
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %v", ErrErrorInvalidURL, field, err)
+	}
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("%w: %s: unsupported scheme %q", ErrErrorInvalidURL, field, parsed.Scheme)
+	}
+	if parsed.Host == "" || parsed.Hostname() == "" {
+		return "", fmt.Errorf("%w: %s: missing host", ErrErrorInvalidURL, field)
+	}
+	if parsed.User != nil || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", fmt.Errorf("%w: %s: must not contain credentials, a query or a fragment", ErrErrorInvalidURL, field)
+	}
+	parsed.Host = strings.ToLower(parsed.Host)
+	parsed.Path = strings.TrimRight(parsed.Path, "/")
+	parsed.RawPath = ""
+	return parsed.String(), nil
+}
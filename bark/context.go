@@ -0,0 +1,99 @@
+package bark
+
+import "context"
+
+// callWithContext runs call and returns its result, or ctx.Err() if ctx is
+// done first. cgo calls cannot be interrupted, so call keeps running on its
+// own goroutine when abandoned; its result is dropped, and any native
+// buffers it returns have already been lifted and freed by then. call is not
+// started at all if ctx is already done.
+func callWithContext[T any](ctx context.Context, call func() (T, error)) (T, error) {
+	if err := ctx.Err(); err != nil {
+		var zero T
+		return zero, err
+	}
+	if ctx.Done() == nil {
+		return call()
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+	// Buffered so the goroutine can finish even if nobody receives.
+	done := make(chan result, 1)
+	go func() {
+		value, err := call()
+		done <- result{value, err}
+	}()
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// callWithContextErr is callWithContext for calls that only return an
+// error.
+func callWithContextErr(ctx context.Context, call func() error) error {
+	_, err := callWithContext(ctx, func() (struct{}, error) {
+		return struct{}{}, call()
+	})
+	return err
+}
+
+// The methods below are the context-aware variants of the wallet methods
+// that make round trips to the ASP. Each returns ctx.Err() if ctx is done
+// before the call finishes; as with SyncContext, the abandoned call still
+// runs to completion in the background.
+
+// BoardAllContext is BoardAll with cancellation.
+func (_self *Wallet) BoardAllContext(ctx context.Context) error {
+	return callWithContextErr(ctx, _self.BoardAll)
+}
+
+// RefreshAllContext is RefreshAll with cancellation.
+func (_self *Wallet) RefreshAllContext(ctx context.Context) error {
+	return callWithContextErr(ctx, _self.RefreshAll)
+}
+
+// OffboardAllContext is OffboardAll with cancellation.
+func (_self *Wallet) OffboardAllContext(ctx context.Context) error {
+	return callWithContextErr(ctx, _self.OffboardAll)
+}
+
+// ExitAllContext is ExitAll with cancellation.
+func (_self *Wallet) ExitAllContext(ctx context.Context) error {
+	return callWithContextErr(ctx, _self.ExitAll)
+}
+
+// MaintenanceContext is Maintenance with cancellation.
+func (_self *Wallet) MaintenanceContext(ctx context.Context) error {
+	return callWithContextErr(ctx, _self.Maintenance)
+}
+
+// PayBolt11Context is PayBolt11 with cancellation. A payment abandoned by
+// ctx may still succeed; check the movements before retrying it.
+func (_self *Wallet) PayBolt11Context(ctx context.Context, invoice Bolt11Invoice, amountSats *uint64) (string, error) {
+	return callWithContext(ctx, func() (string, error) {
+		return _self.PayBolt11(invoice, amountSats)
+	})
+}
+
+// SendContext is Send with cancellation. A send abandoned by ctx may still
+// succeed; check the movements before retrying it.
+func (_self *Wallet) SendContext(ctx context.Context, destination BarkAddress, amountSats uint64) ([]Vtxo, error) {
+	return callWithContext(ctx, func() ([]Vtxo, error) {
+		return _self.Send(destination, amountSats)
+	})
+}
+
+// SendOnchainContext is SendOnchain with cancellation. A send abandoned by
+// ctx may still succeed; check the onchain transactions before retrying it.
+func (_self *Wallet) SendOnchainContext(ctx context.Context, address string, amountSats uint64) (string, error) {
+	return callWithContext(ctx, func() (string, error) {
+		return _self.SendOnchain(address, amountSats)
+	})
+}
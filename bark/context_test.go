@@ -0,0 +1,114 @@
+package bark
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCallWithContext(t *testing.T) {
+	errCall := errors.New("call failed")
+
+	tests := []struct {
+		name string
+		ctx  func() (context.Context, context.CancelFunc)
+		// cancelOnStart cancels the context once the call has started.
+		cancelOnStart bool
+		call          func(release <-chan struct{}) (int, error)
+		wantValue     int
+		wantErr       error
+		wantRun       bool
+	}{
+		{
+			name:      "completes",
+			ctx:       func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			call:      func(<-chan struct{}) (int, error) { return 42, nil },
+			wantValue: 42,
+			wantRun:   true,
+		},
+		{
+			name:    "call error",
+			ctx:     func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			call:    func(<-chan struct{}) (int, error) { return 0, errCall },
+			wantErr: errCall,
+			wantRun: true,
+		},
+		{
+			name: "cancelled before start",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			call:    func(<-chan struct{}) (int, error) { return 42, nil },
+			wantErr: context.Canceled,
+		},
+		{
+			name:          "cancelled before completion",
+			ctx:           func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			cancelOnStart: true,
+			call: func(release <-chan struct{}) (int, error) {
+				<-release
+				return 42, nil
+			},
+			wantErr: context.Canceled,
+			wantRun: true,
+		},
+		{
+			name: "deadline before completion",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 10*time.Millisecond)
+			},
+			call: func(release <-chan struct{}) (int, error) {
+				<-release
+				return 42, nil
+			},
+			wantErr: context.DeadlineExceeded,
+			wantRun: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := tt.ctx()
+			defer cancel()
+			release := make(chan struct{})
+			defer close(release)
+
+			var ran atomic.Bool
+			value, err := callWithContext(ctx, func() (int, error) {
+				ran.Store(true)
+				if tt.cancelOnStart {
+					cancel()
+				}
+				return tt.call(release)
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if value != tt.wantValue {
+				t.Errorf("value = %d, want %d", value, tt.wantValue)
+			}
+			if ran.Load() != tt.wantRun {
+				t.Errorf("call ran = %v, want %v", ran.Load(), tt.wantRun)
+			}
+		})
+	}
+}
+
+func TestCallWithContextErr(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+
+	err := callWithContextErr(ctx, func() error {
+		cancel()
+		<-release
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want %v", err, context.Canceled)
+	}
+}
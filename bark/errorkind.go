@@ -0,0 +1,107 @@
+package bark
+
+import "strconv"
+
+// ErrorKind identifies the variant of an Error returned by the native
+// library.
+type ErrorKind uint
+
+const (
+	ErrorKindUnknown ErrorKind = iota
+	ErrorKindBarkDbFileNotAccessible
+	ErrorKindBarkDbFileAlreadyExists
+	ErrorKindInvalidNetwork
+	ErrorKindInvalidPublicKey
+	ErrorKindInvalidMnemonic
+	ErrorKindInvalidBolt11Invoice
+	ErrorKindInvalidBitcoinAddress
+	ErrorKindInvalidBarkAddress
+	ErrorKindInvalidPaymentHash
+	ErrorKindBarkFailed
+)
+
+var errorKindNames = map[ErrorKind]string{
+	ErrorKindBarkDbFileNotAccessible: "bark_db_file_not_accessible",
+	ErrorKindBarkDbFileAlreadyExists: "bark_db_file_already_exists",
+	ErrorKindInvalidNetwork:          "invalid_network",
+	ErrorKindInvalidPublicKey:        "invalid_public_key",
+	ErrorKindInvalidMnemonic:         "invalid_mnemonic",
+	ErrorKindInvalidBolt11Invoice:    "invalid_bolt11_invoice",
+	ErrorKindInvalidBitcoinAddress:   "invalid_bitcoin_address",
+	ErrorKindInvalidBarkAddress:      "invalid_bark_address",
+	ErrorKindInvalidPaymentHash:      "invalid_payment_hash",
+	ErrorKindBarkFailed:              "bark_failed",
+}
+
+// String returns the kind as a stable lower-case token such as
+// "bark_failed", or "unknown(N)" for a value without a name.
+func (k ErrorKind) String() string {
+	if name, ok := errorKindNames[k]; ok {
+		return name
+	}
+	return "unknown(" + strconv.FormatUint(uint64(k), 10) + ")"
+}
+
+// Kind returns the variant of err, or ErrorKindUnknown for a nil or zero
+// Error.
+func (err *Error) Kind() ErrorKind {
+	if err == nil {
+		return ErrorKindUnknown
+	}
+	switch err.err.(type) {
+	case *ErrorBarkDbFileNotAccessible:
+		return ErrorKindBarkDbFileNotAccessible
+	case *ErrorBarkDbFileAlreadyExists:
+		return ErrorKindBarkDbFileAlreadyExists
+	case *ErrorInvalidNetwork:
+		return ErrorKindInvalidNetwork
+	case *ErrorInvalidPublicKey:
+		return ErrorKindInvalidPublicKey
+	case *ErrorInvalidMnemonic:
+		return ErrorKindInvalidMnemonic
+	case *ErrorInvalidBolt11Invoice:
+		return ErrorKindInvalidBolt11Invoice
+	case *ErrorInvalidBitcoinAddress:
+		return ErrorKindInvalidBitcoinAddress
+	case *ErrorInvalidBarkAddress:
+		return ErrorKindInvalidBarkAddress
+	case *ErrorInvalidPaymentHash:
+		return ErrorKindInvalidPaymentHash
+	case *ErrorBarkFailed:
+		return ErrorKindBarkFailed
+	default:
+		return ErrorKindUnknown
+	}
+}
+
+// Message returns the detail the native library gave with err, without the
+// variant name that Error() prefixes it with. It is empty if there is none.
+func (err *Error) Message() string {
+	if err == nil {
+		return ""
+	}
+	switch variant := err.err.(type) {
+	case *ErrorBarkDbFileNotAccessible:
+		return variant.message
+	case *ErrorBarkDbFileAlreadyExists:
+		return variant.message
+	case *ErrorInvalidNetwork:
+		return variant.message
+	case *ErrorInvalidPublicKey:
+		return variant.message
+	case *ErrorInvalidMnemonic:
+		return variant.message
+	case *ErrorInvalidBolt11Invoice:
+		return variant.message
+	case *ErrorInvalidBitcoinAddress:
+		return variant.message
+	case *ErrorInvalidBarkAddress:
+		return variant.message
+	case *ErrorInvalidPaymentHash:
+		return variant.message
+	case *ErrorBarkFailed:
+		return variant.message
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,90 @@
+package bark
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// errorVariants lists each native error variant by its wire code.
+var errorVariants = []struct {
+	code     uint32
+	kind     ErrorKind
+	sentinel error
+}{
+	{1, ErrorKindBarkDbFileNotAccessible, ErrErrorBarkDbFileNotAccessible},
+	{2, ErrorKindBarkDbFileAlreadyExists, ErrErrorBarkDbFileAlreadyExists},
+	{3, ErrorKindInvalidNetwork, ErrErrorInvalidNetwork},
+	{4, ErrorKindInvalidPublicKey, ErrErrorInvalidPublicKey},
+	{5, ErrorKindInvalidMnemonic, ErrErrorInvalidMnemonic},
+	{6, ErrorKindInvalidBolt11Invoice, ErrErrorInvalidBolt11Invoice},
+	{7, ErrorKindInvalidBitcoinAddress, ErrErrorInvalidBitcoinAddress},
+	{8, ErrorKindInvalidBarkAddress, ErrErrorInvalidBarkAddress},
+	{9, ErrorKindInvalidPaymentHash, ErrErrorInvalidPaymentHash},
+	{10, ErrorKindBarkFailed, ErrErrorBarkFailed},
+}
+
+// readTestError reads an Error as the native library would send it: the
+// variant code followed by the message.
+func readTestError(code uint32, message string) *Error {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, code)
+	FfiConverterStringINSTANCE.Write(&buf, message)
+	return FfiConverterError{}.Read(&buf)
+}
+
+func TestErrorKindAndMessage(t *testing.T) {
+	for _, variant := range errorVariants {
+		t.Run(variant.kind.String(), func(t *testing.T) {
+			message := "detail for " + variant.kind.String()
+			err := readTestError(variant.code, message)
+			if err.Kind() != variant.kind {
+				t.Errorf("Kind = %v, want %v", err.Kind(), variant.kind)
+			}
+			if err.Message() != message {
+				t.Errorf("Message = %q, want %q", err.Message(), message)
+			}
+			if !errors.Is(err, variant.sentinel) {
+				t.Errorf("errors.Is(%v, %v) = false", err, variant.sentinel)
+			}
+			if !strings.Contains(err.Error(), message) {
+				t.Errorf("Error() = %q, want it to contain the message", err.Error())
+			}
+		})
+	}
+}
+
+func TestErrorKindNil(t *testing.T) {
+	var err *Error
+	if err.Kind() != ErrorKindUnknown || err.Message() != "" {
+		t.Errorf("nil Error has kind %v and message %q", err.Kind(), err.Message())
+	}
+	if got := (&Error{}).Kind(); got != ErrorKindUnknown {
+		t.Errorf("zero Error has kind %v", got)
+	}
+}
+
+func TestErrorKindString(t *testing.T) {
+	if got := ErrorKindBarkFailed.String(); got != "bark_failed" {
+		t.Errorf("String = %q, want bark_failed", got)
+	}
+	if got := ErrorKind(99).String(); got != "unknown(99)" {
+		t.Errorf("String = %q, want unknown(99)", got)
+	}
+}
+
+func TestFfiDestroyerErrorVariants(t *testing.T) {
+	for _, variant := range errorVariants {
+		err := readTestError(variant.code, "message")
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("Destroy(%v) panicked: %v", variant.kind, r)
+				}
+			}()
+			FfiDestroyerError{}.Destroy(err)
+		}()
+	}
+}
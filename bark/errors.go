@@ -0,0 +1,39 @@
+package bark
+
+import "fmt"
+
+// Err* below are returned by checks done in the Go layer rather than by the
+// native library. Like the generated sentinels they are meant for errors.Is.
+var ErrErrorWalletMetadataUnavailable = fmt.Errorf("ErrorWalletMetadataUnavailable")
+var ErrErrorInvalidLabel = fmt.Errorf("ErrorInvalidLabel")
+var ErrErrorUnknownConfig = fmt.Errorf("ErrorUnknownConfig")
+var ErrErrorEsploraFailed = fmt.Errorf("ErrorEsploraFailed")
+var ErrErrorUnknownPayment = fmt.Errorf("ErrorUnknownPayment")
+var ErrErrorPaymentNotSettled = fmt.Errorf("ErrorPaymentNotSettled")
+var ErrErrorInvalidURL = fmt.Errorf("ErrorInvalidURL")
+var ErrErrorNoSyncHistory = fmt.Errorf("ErrorNoSyncHistory")
+var ErrErrorInvalidTransaction = fmt.Errorf("ErrorInvalidTransaction")
+var ErrErrorInvalidAmount = fmt.Errorf("ErrorInvalidAmount")
+var ErrErrorInsufficientFunds = fmt.Errorf("ErrorInsufficientFunds")
+var ErrErrorDestinationNotAllowed = fmt.Errorf("ErrorDestinationNotAllowed")
+var ErrErrorAspPubkeyMismatch = fmt.Errorf("ErrorAspPubkeyMismatch")
+var ErrErrorWalletAlreadyOpen = fmt.Errorf("ErrorWalletAlreadyOpen")
+var ErrErrorNoMnemonicFingerprint = fmt.Errorf("ErrorNoMnemonicFingerprint")
+var ErrErrorAmountExceedsMaxVtxo = fmt.Errorf("ErrorAmountExceedsMaxVtxo")
+var ErrErrorSendLimitExceeded = fmt.Errorf("ErrorSendLimitExceeded")
+var ErrErrorNoFeeHistory = fmt.Errorf("ErrorNoFeeHistory")
+var ErrErrorInvalidImport = fmt.Errorf("ErrorInvalidImport")
+var ErrErrorPaymentNotReclaimed = fmt.Errorf("ErrorPaymentNotReclaimed")
+var ErrErrorInvalidLnurl = fmt.Errorf("ErrorInvalidLnurl")
+var ErrErrorLnurlFailed = fmt.Errorf("ErrorLnurlFailed")
+var ErrErrorUnknownMovement = fmt.Errorf("ErrorUnknownMovement")
+var ErrErrorEndpointUnreachable = fmt.Errorf("ErrorEndpointUnreachable")
+var ErrErrorNetworkMismatch = fmt.Errorf("ErrorNetworkMismatch")
+var ErrErrorUnknownMovementKind = fmt.Errorf("ErrorUnknownMovementKind")
+var ErrErrorInvalidTimestamp = fmt.Errorf("ErrorInvalidTimestamp")
+var ErrWalletClosed = fmt.Errorf("ErrorWalletClosed")
+var ErrErrorWrongPassphrase = fmt.Errorf("ErrorWrongPassphrase")
+var ErrErrorCorruptBackup = fmt.Errorf("ErrorCorruptBackup")
+var ErrErrorIncompatibleLibrary = fmt.Errorf("ErrorIncompatibleLibrary")
+var ErrErrorInvalidOutPoint = fmt.Errorf("ErrorInvalidOutPoint")
+var ErrErrorBalanceOverflow = fmt.Errorf("ErrorBalanceOverflow")
@@ -0,0 +1,157 @@
+package bark
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// esploraTimeout bounds every request the Go layer makes to Esplora.
+const esploraTimeout = 30 * time.Second
+
+// esploraClient talks to the Esplora HTTP API for the few chain queries the
+// native library does not expose.
+type esploraClient struct {
+	baseURL string
+	http    *http.Client
+}
+
+func newEsploraClient(baseURL string) *esploraClient {
+	return &esploraClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		http:    &http.Client{Timeout: esploraTimeout},
+	}
+}
+
+func (_self *Wallet) esplora() (*esploraClient, error) {
+	config, err := _self.config()
+	if err != nil {
+		return nil, err
+	}
+	return newEsploraClient(config.EsploraAddress), nil
+}
+
+func (c *esploraClient) get(ctx context.Context, path string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrErrorEsploraFailed, err)
+	}
+	return c.do(req)
+}
+
+func (c *esploraClient) do(req *http.Request) (string, error) {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrErrorEsploraFailed, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("%w: reading %s: %v", ErrErrorEsploraFailed, req.URL.Path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %s %s: %s: %s", ErrErrorEsploraFailed,
+			req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return string(body), nil
+}
+
+// tipHeight returns the height of the best block.
+func (c *esploraClient) tipHeight(ctx context.Context) (uint32, error) {
+	body, err := c.get(ctx, "/blocks/tip/height")
+	if err != nil {
+		return 0, err
+	}
+	height, err := strconv.ParseUint(strings.TrimSpace(body), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: bad tip height %q", ErrErrorEsploraFailed, body)
+	}
+	return uint32(height), nil
+}
+
+// broadcast submits a raw transaction and returns its txid.
+func (c *esploraClient) broadcast(ctx context.Context, txHex string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/tx", strings.NewReader(txHex))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrErrorEsploraFailed, err)
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	body, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(body), nil
+}
+
+// esploraTx is the part of Esplora's transaction object the Go layer uses.
+type esploraTx struct {
+	Txid   string `json:"txid"`
+	Weight uint64 `json:"weight"`
+	Fee    uint64 `json:"fee"`
+}
+
+func (c *esploraClient) transaction(ctx context.Context, txid string) (esploraTx, error) {
+	body, err := c.get(ctx, "/tx/"+txid)
+	if err != nil {
+		return esploraTx{}, err
+	}
+	var tx esploraTx
+	if err := json.Unmarshal([]byte(body), &tx); err != nil {
+		return esploraTx{}, fmt.Errorf("%w: decoding transaction %s: %v", ErrErrorEsploraFailed, txid, err)
+	}
+	return tx, nil
+}
+
+// vsize is the virtual size of the transaction in vbytes.
+func (tx esploraTx) vsize() uint64 {
+	return (tx.Weight + 3) / 4
+}
+
+// feeRate returns the fee rate, in sat/vB rounded up, that Esplora estimates
+// will confirm within targetBlocks. If there is no estimate for exactly that
+// target, the closest faster target is used, falling back to the fastest
+// estimate there is.
+func (c *esploraClient) feeRate(ctx context.Context, targetBlocks uint32) (uint64, error) {
+	body, err := c.get(ctx, "/fee-estimates")
+	if err != nil {
+		return 0, err
+	}
+	var estimates map[string]float64
+	if err := json.Unmarshal([]byte(body), &estimates); err != nil {
+		return 0, fmt.Errorf("%w: decoding fee estimates: %v", ErrErrorEsploraFailed, err)
+	}
+	var (
+		best, fastest         float64
+		bestTarget, minTarget uint64
+	)
+	for key, rate := range estimates {
+		target, err := strconv.ParseUint(key, 10, 32)
+		if err != nil {
+			continue
+		}
+		if target <= uint64(targetBlocks) && target > bestTarget {
+			best, bestTarget = rate, target
+		}
+		if minTarget == 0 || target < minTarget {
+			fastest, minTarget = rate, target
+		}
+	}
+	if minTarget == 0 {
+		return 0, fmt.Errorf("%w: no fee estimates", ErrErrorEsploraFailed)
+	}
+	if bestTarget == 0 {
+		best = fastest
+	}
+	rate := uint64(math.Ceil(best))
+	if rate < 1 {
+		rate = 1
+	}
+	return rate, nil
+}
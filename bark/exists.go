@@ -0,0 +1,127 @@
+package bark
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// WalletExists reports whether there is a wallet at path: a file, or a
+// non-empty directory.
+func WalletExists(path string) (bool, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !info.IsDir() {
+		return true, nil
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return false, err
+	}
+	return len(entries) > 0, nil
+}
+
+// WalletMatchesMnemonic reports whether the wallet at path belongs to
+// mnemonic, without opening it. It compares against a salted fingerprint of
+// the seed that CreateWallet and OpenWallet record in the wallet metadata,
+// so it fails with ErrErrorNoMnemonicFingerprint for a wallet that has not
+// been created or opened by this package.
+func WalletMatchesMnemonic(path string, mnemonic string) (bool, error) {
+	exists, err := WalletExists(path)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return false, fmt.Errorf("%w: no wallet at %s", ErrErrorBarkDbFileNotAccessible, path)
+	}
+	store, err := openMetaStore(path)
+	if err != nil {
+		return false, err
+	}
+	var fingerprint *mnemonicFingerprint
+	store.view(func(meta *walletMeta) {
+		fingerprint = meta.MnemonicFingerprint
+	})
+	if fingerprint == nil {
+		return false, ErrErrorNoMnemonicFingerprint
+	}
+	return fingerprint.matches(mnemonic)
+}
+
+// mnemonicFingerprint identifies a mnemonic without revealing it: an HMAC
+// of its BIP39 seed under a random salt.
+type mnemonicFingerprint struct {
+	Salt string `json:"salt"`
+	Hmac string `json:"hmac"`
+}
+
+func newMnemonicFingerprint(mnemonic string) (*mnemonicFingerprint, error) {
+	var salt [16]byte
+	if _, err := rand.Read(salt[:]); err != nil {
+		return nil, err
+	}
+	sum, err := fingerprintHmac(salt[:], mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	return &mnemonicFingerprint{
+		Salt: hex.EncodeToString(salt[:]),
+		Hmac: hex.EncodeToString(sum),
+	}, nil
+}
+
+func (f *mnemonicFingerprint) matches(mnemonic string) (bool, error) {
+	salt, err := hex.DecodeString(f.Salt)
+	if err != nil {
+		return false, fmt.Errorf("decoding fingerprint salt: %w", err)
+	}
+	want, err := hex.DecodeString(f.Hmac)
+	if err != nil {
+		return false, fmt.Errorf("decoding fingerprint: %w", err)
+	}
+	got, err := fingerprintHmac(salt, mnemonic)
+	if err != nil {
+		return false, err
+	}
+	return hmac.Equal(want, got), nil
+}
+
+func fingerprintHmac(salt []byte, mnemonic string) ([]byte, error) {
+	seed, err := mnemonicToSeed(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(seed)
+	return mac.Sum(nil), nil
+}
+
+// recordMnemonicFingerprint stores the fingerprint of mnemonic for the
+// wallet if it does not have one yet. Mnemonics this package cannot decode
+// are skipped.
+func recordMnemonicFingerprint(store *metaStore, mnemonic string) error {
+	var known bool
+	store.view(func(meta *walletMeta) {
+		known = meta.MnemonicFingerprint != nil
+	})
+	if known {
+		return nil
+	}
+	fingerprint, err := newMnemonicFingerprint(mnemonic)
+	if err != nil {
+		return nil
+	}
+	return store.update(func(meta *walletMeta) error {
+		meta.MnemonicFingerprint = fingerprint
+		return nil
+	})
+}
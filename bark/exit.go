@@ -0,0 +1,63 @@
+package bark
+
+// ExitStage is a step of a unilateral exit as reported by
+// ExitAllWithProgress.
+type ExitStage uint
+
+const (
+	// ExitStageStarted is reported before the exit transactions are built.
+	ExitStageStarted ExitStage = iota + 1
+	// ExitStageBroadcast is reported once the native exit call has built
+	// and broadcast its transactions.
+	ExitStageBroadcast
+	// ExitStageAwaitingConfirmation is reported when the exit is not yet
+	// done after broadcasting.
+	ExitStageAwaitingConfirmation
+	// ExitStageDone is reported when the exit has completed.
+	ExitStageDone
+)
+
+// ExitProgress describes how far ExitAllWithProgress has got.
+type ExitProgress struct {
+	Stage ExitStage
+	// Vtxos is the number of vtxos being exited.
+	Vtxos uint32
+	// Height is the height reported by ExitStatus, once known.
+	Height *uint32
+}
+
+// ExitAllWithProgress runs ExitAll and reports each stage to cb as it is
+// reached. It returns once the transactions are broadcast and does not wait
+// for them to confirm; poll ExitStatus for that.
+//
+// The native exit call builds and broadcasts all transactions in one go, so
+// progress within the broadcast step is not available.
+func (_self *Wallet) ExitAllWithProgress(cb func(ExitProgress)) error {
+	vtxos, err := _self.Vtxos()
+	if err != nil {
+		return err
+	}
+	progress := ExitProgress{Vtxos: uint32(len(vtxos))}
+
+	progress.Stage = ExitStageStarted
+	cb(progress)
+
+	if err := _self.ExitAll(); err != nil {
+		return err
+	}
+	progress.Stage = ExitStageBroadcast
+	cb(progress)
+
+	status, err := _self.ExitStatus()
+	if err != nil {
+		return err
+	}
+	progress.Height = status.Height
+	if status.Done {
+		progress.Stage = ExitStageDone
+	} else {
+		progress.Stage = ExitStageAwaitingConfirmation
+	}
+	cb(progress)
+	return nil
+}
@@ -0,0 +1,144 @@
+package bark
+
+import "sync"
+
+// expiryWatch is the handler registered with OnVtxoExpiring along with the
+// vtxos it has already been told about.
+type expiryWatch struct {
+	mu           sync.Mutex
+	withinBlocks uint32
+	callback     func([]Vtxo)
+	notified     map[string]struct{}
+}
+
+// OnVtxoExpiring registers cb to be called at the end of Sync and
+// Maintenance with the vtxos that have come within withinBlocks of their
+// expiry height since the last call. Each vtxo is reported once for as long
+// as the wallet stays open. Registering again replaces the previous handler;
+// a nil cb removes it.
+//
+// cb runs on the syncing goroutine before any automatic refresh.
+func (_self *Wallet) OnVtxoExpiring(withinBlocks uint32, cb func([]Vtxo)) {
+	watch := &_self.state.expiry
+	watch.mu.Lock()
+	defer watch.mu.Unlock()
+	watch.withinBlocks = withinBlocks
+	watch.callback = cb
+	watch.notified = make(map[string]struct{})
+}
+
+func (_self *Wallet) hasExpiryWatch() bool {
+	watch := &_self.state.expiry
+	watch.mu.Lock()
+	defer watch.mu.Unlock()
+	return watch.callback != nil
+}
+
+func (_self *Wallet) notifyExpiring(height uint32) error {
+	watch := &_self.state.expiry
+	watch.mu.Lock()
+	callback := watch.callback
+	watch.mu.Unlock()
+	if callback == nil {
+		return nil
+	}
+
+	vtxos, err := _self.Vtxos()
+	if err != nil {
+		return err
+	}
+
+	watch.mu.Lock()
+	var fresh []Vtxo
+	current := make(map[string]struct{}, len(vtxos))
+	for _, vtxo := range expiringVtxos(vtxos, height, watch.withinBlocks) {
+		key := vtxo.Point.String()
+		current[key] = struct{}{}
+		if _, ok := watch.notified[key]; !ok {
+			fresh = append(fresh, vtxo)
+		}
+	}
+	// Forget vtxos that have been spent or refreshed so the set stays small.
+	watch.notified = current
+	watch.mu.Unlock()
+
+	if len(fresh) > 0 {
+		callback(fresh)
+	}
+	return nil
+}
+
+// DefaultExpiresSoonBlocks is the VtxoExpiryInfo threshold used until
+// SetExpiresSoonWithinBlocks is called: about a day of blocks.
+const DefaultExpiresSoonBlocks = 144
+
+// VtxoExpiry is a vtxo with its distance from expiry at the current tip.
+type VtxoExpiry struct {
+	Vtxo Vtxo
+	// BlocksRemaining is ExpiryHeight minus the tip height; it is negative
+	// once the vtxo has expired.
+	BlocksRemaining int64
+	// ExpiresSoon is set when BlocksRemaining is at most the threshold set
+	// with SetExpiresSoonWithinBlocks, including for expired vtxos.
+	ExpiresSoon bool
+}
+
+// SetExpiresSoonWithinBlocks sets how close to expiry, in blocks, a vtxo
+// must be for VtxoExpiryInfo to mark it as expiring soon. Pass nil to go back
+// to DefaultExpiresSoonBlocks. The setting is persisted with the wallet.
+func (_self *Wallet) SetExpiresSoonWithinBlocks(blocks *uint32) error {
+	store, err := _self.metaStore()
+	if err != nil {
+		return err
+	}
+	return store.update(func(meta *walletMeta) error {
+		meta.ExpiresSoonWithinBlocks = blocks
+		return nil
+	})
+}
+
+func (_self *Wallet) expiresSoonWithinBlocks() uint32 {
+	store, err := _self.metaStore()
+	if err != nil {
+		return DefaultExpiresSoonBlocks
+	}
+	var blocks *uint32
+	store.view(func(meta *walletMeta) {
+		blocks = meta.ExpiresSoonWithinBlocks
+	})
+	if blocks == nil {
+		return DefaultExpiresSoonBlocks
+	}
+	return *blocks
+}
+
+// VtxoExpiryInfo returns the wallet's vtxos, in the order of Vtxos, with how
+// many blocks each has left before it expires. The tip is the one returned by
+// BlockHeight, so it fails with ErrErrorNoSyncHistory until the wallet has
+// synced.
+func (_self *Wallet) VtxoExpiryInfo() ([]VtxoExpiry, error) {
+	height, err := _self.BlockHeight()
+	if err != nil {
+		return nil, err
+	}
+	vtxos, err := _self.Vtxos()
+	if err != nil {
+		return nil, err
+	}
+	return vtxoExpiryInfo(vtxos, height, _self.expiresSoonWithinBlocks()), nil
+}
+
+// vtxoExpiryInfo measures each vtxo's distance from expiry at height,
+// marking those within the given number of blocks as expiring soon.
+func vtxoExpiryInfo(vtxos []Vtxo, height uint32, within uint32) []VtxoExpiry {
+	infos := make([]VtxoExpiry, 0, len(vtxos))
+	for _, vtxo := range vtxos {
+		remaining := int64(vtxo.ExpiryHeight) - int64(height)
+		infos = append(infos, VtxoExpiry{
+			Vtxo:            vtxo,
+			BlocksRemaining: remaining,
+			ExpiresSoon:     remaining <= int64(within),
+		})
+	}
+	return infos
+}
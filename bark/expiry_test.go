@@ -0,0 +1,78 @@
+package bark
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVtxoExpiryInfo(t *testing.T) {
+	const height = 800_000
+	tests := []struct {
+		name          string
+		expiryHeight  uint32
+		wantRemaining int64
+		wantSoon      bool
+	}{
+		{"expired", height - 10, -10, true},
+		{"expires at the tip", height, 0, true},
+		{"at the threshold", height + DefaultExpiresSoonBlocks, DefaultExpiresSoonBlocks, true},
+		{"just past the threshold", height + DefaultExpiresSoonBlocks + 1, DefaultExpiresSoonBlocks + 1, false},
+		{"well in the future", height + 20_000, 20_000, false},
+	}
+	vtxos := make([]Vtxo, len(tests))
+	for i, tt := range tests {
+		vtxos[i] = Vtxo{AmountSat: uint64(i + 1), ExpiryHeight: tt.expiryHeight}
+	}
+
+	infos := vtxoExpiryInfo(vtxos, height, DefaultExpiresSoonBlocks)
+	if len(infos) != len(tests) {
+		t.Fatalf("got %d infos, want %d", len(infos), len(tests))
+	}
+	for i, tt := range tests {
+		info := infos[i]
+		if info.Vtxo != vtxos[i] {
+			t.Errorf("%s: vtxo = %+v, want %+v", tt.name, info.Vtxo, vtxos[i])
+		}
+		if info.BlocksRemaining != tt.wantRemaining {
+			t.Errorf("%s: BlocksRemaining = %d, want %d", tt.name, info.BlocksRemaining, tt.wantRemaining)
+		}
+		if info.ExpiresSoon != tt.wantSoon {
+			t.Errorf("%s: ExpiresSoon = %t, want %t", tt.name, info.ExpiresSoon, tt.wantSoon)
+		}
+	}
+}
+
+func TestVtxoExpiryInfoEmpty(t *testing.T) {
+	infos := vtxoExpiryInfo(nil, 800_000, DefaultExpiresSoonBlocks)
+	if infos == nil || len(infos) != 0 {
+		t.Errorf("vtxoExpiryInfo(nil) = %#v, want an empty slice", infos)
+	}
+}
+
+func TestVtxoExpiryInfoNeverSynced(t *testing.T) {
+	wallet, _ := newTestWallet(t)
+	if _, err := wallet.VtxoExpiryInfo(); !errors.Is(err, ErrErrorNoSyncHistory) {
+		t.Fatalf("VtxoExpiryInfo error = %v, want ErrErrorNoSyncHistory", err)
+	}
+}
+
+func TestExpiresSoonWithinBlocks(t *testing.T) {
+	wallet, _ := newTestWallet(t)
+	withTestMeta(t, wallet)
+	if got := wallet.expiresSoonWithinBlocks(); got != DefaultExpiresSoonBlocks {
+		t.Errorf("default threshold = %d, want %d", got, DefaultExpiresSoonBlocks)
+	}
+	blocks := uint32(6)
+	if err := wallet.SetExpiresSoonWithinBlocks(&blocks); err != nil {
+		t.Fatal(err)
+	}
+	if got := wallet.expiresSoonWithinBlocks(); got != 6 {
+		t.Errorf("threshold = %d, want 6", got)
+	}
+	if err := wallet.SetExpiresSoonWithinBlocks(nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := wallet.expiresSoonWithinBlocks(); got != DefaultExpiresSoonBlocks {
+		t.Errorf("threshold after reset = %d, want %d", got, DefaultExpiresSoonBlocks)
+	}
+}
@@ -0,0 +1,131 @@
+package bark
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"unsafe"
+)
+
+// fakeNative stands in for a native wallet behind an FfiObject. It counts
+// the strong references the Go side holds, as the native Arc would.
+type fakeNative struct {
+	refs atomic.Int64
+	// overFreed is set if a reference is released more often than taken.
+	overFreed atomic.Bool
+}
+
+// alive reports whether the native object still has a reference.
+func (n *fakeNative) alive() bool {
+	return n.refs.Load() > 0
+}
+
+// use checks that the native object is alive, as an FFI call using it
+// would need, and then releases the reference the call was handed.
+func (n *fakeNative) use(t *testing.T, pointer unsafe.Pointer) {
+	t.Helper()
+	if (*fakeNative)(pointer) != n {
+		t.Errorf("got pointer %p, want %p", pointer, n)
+	}
+	if !n.alive() {
+		t.Error("native object used after it was freed")
+	}
+	fakeFree[struct{}](pointer, nil)
+}
+
+// fakeClone and fakeFree take the place of the generated clone and free
+// functions. They are generic in the call status so that tests, which
+// cannot refer to cgo types, can pass them to newFfiObject.
+func fakeClone[S any](pointer unsafe.Pointer, _ *S) unsafe.Pointer {
+	(*fakeNative)(pointer).refs.Add(1)
+	return pointer
+}
+
+func fakeFree[S any](pointer unsafe.Pointer, _ *S) {
+	native := (*fakeNative)(pointer)
+	if native.refs.Add(-1) < 0 {
+		native.overFreed.Store(true)
+	}
+}
+
+// newTestWallet returns a wallet backed by a fakeNative instead of the
+// native library. Only methods that stay in the Go layer can be called, and
+// not Handle, whose handles are lifted with the native clone and free
+// functions.
+func newTestWallet(t *testing.T) (*Wallet, *fakeNative) {
+	t.Helper()
+	native := &fakeNative{}
+	native.refs.Store(1)
+	wallet := &Wallet{
+		ffiObject: newFfiObject(unsafe.Pointer(native), fakeClone, fakeFree),
+		state:     &walletState{},
+	}
+	t.Cleanup(func() {
+		if native.overFreed.Load() {
+			t.Error("native object freed more often than referenced")
+		}
+	})
+	return wallet, native
+}
+
+// withTestMeta gives wallet a metadata store in a temporary directory, as
+// attachWalletState would for a wallet at that path.
+func withTestMeta(t *testing.T, wallet *Wallet) {
+	t.Helper()
+	path := t.TempDir()
+	store, err := openMetaStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wallet.state.path = path
+	wallet.state.meta = store
+}
+
+// withTestEsplora records a config for wallet whose Esplora is a test
+// server answering with handler. wallet must have a metadata store.
+func withTestEsplora(t *testing.T, wallet *Wallet, handler http.HandlerFunc) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	config := validConfig()
+	config.EsploraAddress = server.URL
+	err := wallet.state.meta.update(func(meta *walletMeta) error {
+		meta.Config = &config
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// esploraTip returns a handler for an Esplora whose chain tip is at height.
+func esploraTip(height string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/blocks/tip/height" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(height))
+	}
+}
+
+// testArkAddress returns a well-formed signet Ark address, a bech32m string
+// with the "tark" prefix, whose data is derived from seed.
+func testArkAddress(seed byte) BarkAddress {
+	const hrp = "tark"
+	data := make([]byte, 40)
+	for i := range data {
+		data[i] = (seed + byte(i)) & 31
+	}
+	values := append(bech32ExpandHrp(hrp), data...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ 0x2bc830a3
+	address := []byte(hrp + "1")
+	for _, v := range data {
+		address = append(address, bech32Charset[v])
+	}
+	for i := range 6 {
+		address = append(address, bech32Charset[(polymod>>(5*(5-i)))&31])
+	}
+	return string(address)
+}
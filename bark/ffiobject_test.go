@@ -0,0 +1,64 @@
+package bark
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWalletLowerKeepsObjectAlive(t *testing.T) {
+	wallet, native := newTestWallet(t)
+	pointer := FfiConverterWalletINSTANCE.Lower(wallet)
+	wallet.Destroy()
+	if !native.alive() {
+		t.Fatal("destroying the wallet freed the reference handed to the FFI call")
+	}
+	native.use(t, pointer)
+	if native.alive() {
+		t.Errorf("%d references left after the FFI call released its own", native.refs.Load())
+	}
+}
+
+// TestWalletLowerDestroyStress lowers wallets into simulated FFI calls while
+// other goroutines destroy them. Run with -race. A Lower that starts after
+// Destroy panics as for any destroyed object; every Lower that succeeds
+// must hand over a reference that stays valid until the call releases it.
+func TestWalletLowerDestroyStress(t *testing.T) {
+	const wallets = 200
+	const lowersPerWallet = 20
+
+	var wg sync.WaitGroup
+	natives := make([]*fakeNative, wallets)
+	for i := range wallets {
+		wallet, native := newTestWallet(t)
+		natives[i] = native
+
+		for range lowersPerWallet {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() {
+					if r := recover(); r != nil {
+						if !strings.Contains(r.(error).Error(), "already been destroyed") {
+							t.Errorf("unexpected panic: %v", r)
+						}
+					}
+				}()
+				pointer := FfiConverterWalletINSTANCE.Lower(wallet)
+				native.use(t, pointer)
+			}()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wallet.Destroy()
+		}()
+	}
+	wg.Wait()
+
+	for i, native := range natives {
+		if refs := native.refs.Load(); refs != 0 {
+			t.Errorf("wallet %d has %d references left, want 0", i, refs)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package bark
+
+// Handle returns a second handle to the same wallet, for example for a
+// background goroutine whose lifetime is independent of the caller's. Both
+// handles share the native wallet and the Go layer's state (observers,
+// limits, metadata), so a setting changed through one applies to the other.
+//
+// Each handle owns its own reference and must be destroyed separately. The
+// wallet stays open, and keeps its path registered, until every handle is
+// destroyed; destroying one never invalidates another.
+func (_self *Wallet) Handle() *Wallet {
+	_self.state.handles.Add(1)
+	handle := FfiConverterWalletINSTANCE.Lift(_self.ffiObject.clonePointer("*Wallet"))
+	handle.state = _self.state
+	return handle
+}
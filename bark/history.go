@@ -0,0 +1,115 @@
+package bark
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// HistoryKindOnchain is the HistoryEntry kind of onchain transactions.
+// Movements use the String form of their MovementKind.
+const HistoryKindOnchain = "onchain"
+
+// HistoryEntry is an item in the merged activity feed returned by History.
+type HistoryEntry struct {
+	Timestamp time.Time
+	Kind      string
+	// AmountSat is positive for funds coming in and negative for funds
+	// going out. For movements it is the amount received minus the amount
+	// sent.
+	AmountSat int64
+	// Reference is the txid of an onchain transaction or the decimal id of
+	// a movement, prefixed with "imported:" for imported movements.
+	Reference string
+}
+
+// Onchain transaction types reported by the native library.
+const (
+	onchainTxIncoming = "incoming"
+	onchainTxOutgoing = "outgoing"
+)
+
+// History merges Movements and OnchainTransactions into one feed, newest
+// first. Movement timestamps are parsed as by Movement.CreatedTime and
+// onchain ones are taken as Unix seconds. It fails with
+// ErrErrorInvalidTransaction for an onchain transaction type other than
+// incoming or outgoing.
+//
+// A board's funding transaction is left out while the wallet holds the
+// vtxo it created, since the board movement already shows it. Movements do
+// not carry txids, so offboard and exit transactions, and board ones whose
+// vtxo has been spent, cannot be tied to their movement and are listed as
+// well.
+func (_self *Wallet) History() ([]HistoryEntry, error) {
+	movements, err := _self.Movements()
+	if err != nil {
+		return nil, err
+	}
+	vtxos, err := _self.Vtxos()
+	if err != nil {
+		return nil, err
+	}
+	return mergeHistory(movements, _self.OnchainTransactions(), vtxos)
+}
+
+// mergeHistory builds History from movements, onchain transactions and the
+// wallet's vtxos.
+func mergeHistory(movements []Movement, transactions []OnchainTransaction, vtxos []Vtxo) ([]HistoryEntry, error) {
+	// Only board vtxos sit on an onchain transaction; the others are on
+	// virtual ones, which never match.
+	boards := make(map[string]struct{}, len(vtxos))
+	for _, vtxo := range vtxos {
+		boards[vtxo.Point.Txid] = struct{}{}
+	}
+
+	history := make([]HistoryEntry, 0, len(movements)+len(transactions))
+	for _, movement := range movements {
+		created, err := movement.CreatedTime()
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, HistoryEntry{
+			Timestamp: created,
+			Kind:      movement.Kind.String(),
+			AmountSat: int64(movement.AmountReceivedSat) - int64(movement.AmountSentSat),
+			Reference: movementReference(movement),
+		})
+	}
+	for _, tx := range transactions {
+		if _, ok := boards[tx.Txid]; ok {
+			continue
+		}
+		amount := int64(tx.AmountSat)
+		switch tx.TxType {
+		case onchainTxIncoming:
+		case onchainTxOutgoing:
+			amount = -amount
+		default:
+			return nil, fmt.Errorf("%w: %s has type %q", ErrErrorInvalidTransaction, tx.Txid, tx.TxType)
+		}
+		history = append(history, HistoryEntry{
+			Timestamp: time.Unix(int64(tx.CreatedAt), 0).UTC(),
+			Kind:      HistoryKindOnchain,
+			AmountSat: amount,
+			Reference: tx.Txid,
+		})
+	}
+
+	slices.SortStableFunc(history, func(a, b HistoryEntry) int {
+		if c := b.Timestamp.Compare(a.Timestamp); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Reference, b.Reference)
+	})
+	return history, nil
+}
+
+func movementReference(movement Movement) string {
+	reference := strconv.FormatUint(uint64(movement.Id), 10)
+	if movement.Imported {
+		reference = "imported:" + reference
+	}
+	return reference
+}
@@ -0,0 +1,79 @@
+package bark
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMergeHistoryInterleaved(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	movements := []Movement{
+		{Id: 2, Kind: MovementKindArkoorSend, AmountSentSat: 3_000, CreatedAt: "2024-05-01 12:00:00"},
+		{Id: 1, Kind: MovementKindBoard, AmountReceivedSat: 50_000, CreatedAt: "2024-05-01T08:00:00Z"},
+	}
+	transactions := []OnchainTransaction{
+		{Txid: "aa", AmountSat: 60_000, CreatedAt: uint64(day.Add(6 * time.Hour).Unix()), TxType: "incoming"},
+		{Txid: "bb", AmountSat: 10_000, CreatedAt: uint64(day.Add(10 * time.Hour).Unix()), TxType: "outgoing"},
+		// The funding transaction of board 1, already shown by the movement.
+		{Txid: "cc", AmountSat: 50_200, CreatedAt: uint64(day.Add(8 * time.Hour).Unix()), TxType: "outgoing"},
+	}
+	vtxos := []Vtxo{
+		{Point: OutPoint{Txid: "cc", Vout: 0}, AmountSat: 50_000},
+		{Point: OutPoint{Txid: "dd", Vout: 1}, AmountSat: 1_000, IsArkoor: true},
+	}
+
+	history, err := mergeHistory(movements, transactions, vtxos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []HistoryEntry{
+		{day.Add(12 * time.Hour), "arkoor_send", -3_000, "2"},
+		{day.Add(10 * time.Hour), HistoryKindOnchain, -10_000, "bb"},
+		{day.Add(8 * time.Hour), "board", 50_000, "1"},
+		{day.Add(6 * time.Hour), HistoryKindOnchain, 60_000, "aa"},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(history), len(want))
+	}
+	for i := range want {
+		if !history[i].Timestamp.Equal(want[i].Timestamp) {
+			t.Errorf("entry %d timestamp = %v, want %v", i, history[i].Timestamp, want[i].Timestamp)
+		}
+		history[i].Timestamp = want[i].Timestamp
+	}
+	if !reflect.DeepEqual(history, want) {
+		t.Errorf("history = %+v, want %+v", history, want)
+	}
+}
+
+func TestMergeHistorySameTimestamp(t *testing.T) {
+	at := "2024-05-01T08:00:00Z"
+	created, _ := time.Parse(time.RFC3339, at)
+	movements := []Movement{{Id: 7, Kind: MovementKindRound, CreatedAt: at}}
+	transactions := []OnchainTransaction{{Txid: "ff", CreatedAt: uint64(created.Unix()), TxType: "incoming"}}
+
+	history, err := mergeHistory(movements, transactions, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Ties are broken by reference so the order is stable across calls.
+	if len(history) != 2 || history[0].Reference != "7" || history[1].Reference != "ff" {
+		t.Errorf("history = %+v, want the movement and then the transaction", history)
+	}
+}
+
+func TestMergeHistoryBadTimestamp(t *testing.T) {
+	movements := []Movement{{Id: 1, CreatedAt: "yesterday"}}
+	if _, err := mergeHistory(movements, nil, nil); !errors.Is(err, ErrErrorInvalidTimestamp) {
+		t.Fatalf("error = %v, want ErrErrorInvalidTimestamp", err)
+	}
+}
+
+func TestMergeHistoryUnknownTxType(t *testing.T) {
+	transactions := []OnchainTransaction{{Txid: "ee", AmountSat: 1_000, TxType: "send"}}
+	if _, err := mergeHistory(nil, transactions, nil); !errors.Is(err, ErrErrorInvalidTransaction) {
+		t.Fatalf("error = %v, want ErrErrorInvalidTransaction", err)
+	}
+}
@@ -0,0 +1,93 @@
+package bark
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// blockInterval is the expected time between blocks.
+const blockInterval = 10 * time.Minute
+
+// HoldingCostEstimate estimates the round fees, in sats, needed to keep the
+// current vtxos alive for period by refreshing each one as it nears expiry.
+//
+// The ASP's fee schedule is not exposed by the bindings, so the fee rate is
+// taken from the wallet's past round movements: the fees paid per sat
+// refreshed. It fails with ErrErrorNoFeeHistory if the wallet has never paid
+// a round fee. Heights are converted using blockInterval.
+func (_self *Wallet) HoldingCostEstimate(period time.Duration) (uint64, error) {
+	if period < 0 {
+		return 0, fmt.Errorf("%w: negative period", ErrErrorInvalidAmount)
+	}
+	movements, err := _self.Movements()
+	if err != nil {
+		return 0, err
+	}
+	ratio, ok := roundFeeRatio(movements)
+	if !ok {
+		return 0, ErrErrorNoFeeHistory
+	}
+	info, err := _self.ArkInfo()
+	if err != nil {
+		return 0, err
+	}
+	height, err := _self.currentHeight()
+	if err != nil {
+		return 0, err
+	}
+	vtxos, err := _self.Vtxos()
+	if err != nil {
+		return 0, err
+	}
+
+	horizon := uint64(height) + uint64(period/blockInterval)
+	var cost float64
+	for _, vtxo := range vtxos {
+		cost += float64(vtxo.AmountSat) * ratio * float64(refreshesBefore(vtxo.ExpiryHeight, horizon, info.VtxoExpiryDelta))
+	}
+	return uint64(math.Ceil(cost)), nil
+}
+
+// roundFeeRatio is the fee paid per sat moved in past rounds.
+func roundFeeRatio(movements []Movement) (float64, bool) {
+	var fees, amount uint64
+	for _, movement := range movements {
+		if movement.Kind != MovementKindRound || movement.AmountSentSat == 0 {
+			continue
+		}
+		fees += movement.FeesSat
+		amount += movement.AmountSentSat
+	}
+	if amount == 0 || fees == 0 {
+		return 0, false
+	}
+	return float64(fees) / float64(amount), true
+}
+
+// refreshesBefore is how many times a vtxo expiring at expiry must be
+// refreshed to last until horizon, when each refresh extends it by
+// expiryDelta blocks.
+func refreshesBefore(expiry uint32, horizon uint64, expiryDelta uint16) uint64 {
+	if uint64(expiry) > horizon {
+		return 0
+	}
+	if expiryDelta == 0 {
+		return 1
+	}
+	return 1 + (horizon-uint64(expiry))/uint64(expiryDelta)
+}
+
+// currentHeight returns the height seen by the last sync, or asks Esplora if
+// the wallet has not synced since it was opened.
+func (_self *Wallet) currentHeight() (uint32, error) {
+	if height := _self.state.syncedHeight.Load(); height != 0 {
+		return height, nil
+	}
+	esplora, err := _self.esplora()
+	if err != nil {
+		return 0, err
+	}
+	return esplora.tipHeight(context.Background())
+}
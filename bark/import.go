@@ -0,0 +1,166 @@
+package bark
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ImportFormat is the encoding read by ImportMovements.
+type ImportFormat uint
+
+const (
+	// ImportFormatJSON is a JSON array of objects with the fields id, kind,
+	// amount_sent_sat, amount_received_sat, fees_sat and created_at.
+	ImportFormatJSON ImportFormat = iota + 1
+	// ImportFormatCSV is comma separated values with a header row naming
+	// the same fields as ImportFormatJSON, in any order.
+	ImportFormatCSV
+)
+
+// importedMovement is a movement as read by ImportMovements.
+type importedMovement struct {
+	Id                uint32 `json:"id"`
+	Kind              string `json:"kind"`
+	AmountSentSat     uint64 `json:"amount_sent_sat"`
+	AmountReceivedSat uint64 `json:"amount_received_sat"`
+	FeesSat           uint64 `json:"fees_sat"`
+	CreatedAt         string `json:"created_at"`
+}
+
+// ImportMovements reads historical movements from r, for example exported
+// from an earlier wallet, and adds them to those returned by Movements. It
+// returns the number imported. Movements whose id was already imported are
+// skipped. Kinds are in the form printed by MovementKind.String, such as
+// "arkoor_send".
+//
+// Imported movements are kept in the wallet metadata, not the native
+// database, so they never affect the balance. They are listed with Imported
+// set, and their ids are separate from those of native movements, so an
+// imported movement and a native one may have the same id.
+func (_self *Wallet) ImportMovements(r io.Reader, format ImportFormat) (uint32, error) {
+	var records []importedMovement
+	var err error
+	switch format {
+	case ImportFormatJSON:
+		err = json.NewDecoder(r).Decode(&records)
+		if err != nil {
+			err = fmt.Errorf("%w: %v", ErrErrorInvalidImport, err)
+		}
+	case ImportFormatCSV:
+		records, err = readMovementsCSV(r)
+	default:
+		err = fmt.Errorf("%w: unknown format %d", ErrErrorInvalidImport, format)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	movements := make([]Movement, 0, len(records))
+	for i, record := range records {
+		kind, err := ParseMovementKind(record.Kind)
+		if err != nil {
+			return 0, fmt.Errorf("%w: movement %d: %w", ErrErrorInvalidImport, i, err)
+		}
+		movements = append(movements, Movement{
+			Id:                record.Id,
+			Kind:              kind,
+			AmountSentSat:     record.AmountSentSat,
+			AmountReceivedSat: record.AmountReceivedSat,
+			FeesSat:           record.FeesSat,
+			CreatedAt:         record.CreatedAt,
+		})
+	}
+
+	store, err := _self.metaStore()
+	if err != nil {
+		return 0, err
+	}
+	var imported uint32
+	err = store.update(func(meta *walletMeta) error {
+		fresh := addImportedMovements(meta, movements)
+		imported = uint32(len(fresh))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return imported, nil
+}
+
+// addImportedMovements adds to meta the movements whose id is not among its
+// imported movements yet, marked as imported, and returns those added.
+// Native ids play no part.
+func addImportedMovements(meta *walletMeta, movements []Movement) []Movement {
+	seen := make(map[uint32]struct{}, len(meta.ImportedMovements)+len(movements))
+	for _, movement := range meta.ImportedMovements {
+		seen[movement.Id] = struct{}{}
+	}
+	var added []Movement
+	for _, movement := range movements {
+		if _, ok := seen[movement.Id]; ok {
+			continue
+		}
+		seen[movement.Id] = struct{}{}
+		movement.Imported = true
+		added = append(added, movement)
+	}
+	meta.ImportedMovements = append(meta.ImportedMovements, added...)
+	return added
+}
+
+func readMovementsCSV(r io.Reader) ([]importedMovement, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading header: %v", ErrErrorInvalidImport, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{"id", "kind", "amount_sent_sat", "amount_received_sat", "fees_sat", "created_at"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", ErrErrorInvalidImport, name)
+		}
+	}
+
+	var records []importedMovement
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrErrorInvalidImport, err)
+		}
+		number := func(name string, bits int) uint64 {
+			if err != nil {
+				return 0
+			}
+			var n uint64
+			n, err = strconv.ParseUint(row[columns[name]], 10, bits)
+			if err != nil {
+				err = fmt.Errorf("%w: line %d: bad %s %q", ErrErrorInvalidImport, line, name, row[columns[name]])
+			}
+			return n
+		}
+		record := importedMovement{
+			Id:                uint32(number("id", 32)),
+			Kind:              row[columns["kind"]],
+			AmountSentSat:     number("amount_sent_sat", 64),
+			AmountReceivedSat: number("amount_received_sat", 64),
+			FeesSat:           number("fees_sat", 64),
+			CreatedAt:         row[columns["created_at"]],
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+}
@@ -0,0 +1,38 @@
+package bark
+
+import (
+	"fmt"
+	"strconv"
+)
+
+var movementKindNames = map[MovementKind]string{
+	MovementKindBoard:                   "board",
+	MovementKindRound:                   "round",
+	MovementKindOffboard:                "offboard",
+	MovementKindExit:                    "exit",
+	MovementKindArkoorSend:              "arkoor_send",
+	MovementKindArkoorReceive:           "arkoor_receive",
+	MovementKindLightningSend:           "lightning_send",
+	MovementKindLightningSendRevocation: "lightning_send_revocation",
+	MovementKindLightningReceive:        "lightning_receive",
+}
+
+// String returns the kind as a stable lower-case token such as
+// "arkoor_send", or "unknown(N)" for a value without a constant.
+func (k MovementKind) String() string {
+	if name, ok := movementKindNames[k]; ok {
+		return name
+	}
+	return "unknown(" + strconv.FormatUint(uint64(k), 10) + ")"
+}
+
+// ParseMovementKind returns the kind whose String is name. It fails with
+// ErrErrorUnknownMovementKind for any other name.
+func ParseMovementKind(name string) (MovementKind, error) {
+	for kind, kindName := range movementKindNames {
+		if kindName == name {
+			return kind, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrErrorUnknownMovementKind, name)
+}
@@ -0,0 +1,53 @@
+package bark
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMovementKindString(t *testing.T) {
+	tests := []struct {
+		kind MovementKind
+		want string
+	}{
+		{MovementKindBoard, "board"},
+		{MovementKindRound, "round"},
+		{MovementKindOffboard, "offboard"},
+		{MovementKindExit, "exit"},
+		{MovementKindArkoorSend, "arkoor_send"},
+		{MovementKindArkoorReceive, "arkoor_receive"},
+		{MovementKindLightningSend, "lightning_send"},
+		{MovementKindLightningSendRevocation, "lightning_send_revocation"},
+		{MovementKindLightningReceive, "lightning_receive"},
+		{0, "unknown(0)"},
+		{42, "unknown(42)"},
+	}
+	for _, tt := range tests {
+		if got := tt.kind.String(); got != tt.want {
+			t.Errorf("MovementKind(%d).String() = %q, want %q", uint(tt.kind), got, tt.want)
+		}
+	}
+}
+
+func TestParseMovementKindRoundTrip(t *testing.T) {
+	if len(movementKindNames) != 9 {
+		t.Fatalf("movementKindNames has %d kinds, want 9", len(movementKindNames))
+	}
+	for kind := range movementKindNames {
+		parsed, err := ParseMovementKind(kind.String())
+		if err != nil {
+			t.Fatalf("ParseMovementKind(%q): %v", kind.String(), err)
+		}
+		if parsed != kind {
+			t.Errorf("ParseMovementKind(%q) = %d, want %d", kind.String(), parsed, kind)
+		}
+	}
+}
+
+func TestParseMovementKindUnknown(t *testing.T) {
+	for _, name := range []string{"", "Board", "unknown(42)", "send"} {
+		if _, err := ParseMovementKind(name); !errors.Is(err, ErrErrorUnknownMovementKind) {
+			t.Errorf("ParseMovementKind(%q) error = %v, want ErrErrorUnknownMovementKind", name, err)
+		}
+	}
+}
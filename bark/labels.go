@@ -0,0 +1,73 @@
+package bark
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NewAddressLabeled returns an address reserved for label, generating and
+// recording a new one the first time a label is seen. Asking again for the
+// same label returns the same address, so a label can be handed to a payer
+// once and used to attribute everything they send to it.
+func (_self *Wallet) NewAddressLabeled(label string) (BarkAddress, error) {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return "", fmt.Errorf("%w: label must not be empty", ErrErrorInvalidLabel)
+	}
+	store, err := _self.metaStore()
+	if err != nil {
+		return "", err
+	}
+
+	_self.state.labelMu.Lock()
+	defer _self.state.labelMu.Unlock()
+
+	if address, ok := addressForLabel(store, label); ok {
+		return address, nil
+	}
+
+	address, err := _self.NewAddress()
+	if err != nil {
+		return "", err
+	}
+	err = store.update(func(meta *walletMeta) error {
+		if meta.Labels == nil {
+			meta.Labels = make(map[string]string)
+		}
+		meta.Labels[address] = label
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return address, nil
+}
+
+// AddressLabel returns the label address was generated for by
+// NewAddressLabeled.
+func (_self *Wallet) AddressLabel(address BarkAddress) (string, bool) {
+	store, err := _self.metaStore()
+	if err != nil {
+		return "", false
+	}
+	var label string
+	var ok bool
+	store.view(func(meta *walletMeta) {
+		label, ok = meta.Labels[address]
+	})
+	return label, ok
+}
+
+func addressForLabel(store *metaStore, label string) (BarkAddress, bool) {
+	var address BarkAddress
+	var ok bool
+	store.view(func(meta *walletMeta) {
+		for addr, l := range meta.Labels {
+			if l == label {
+				address, ok = addr, true
+				return
+			}
+		}
+	})
+	return address, ok
+}
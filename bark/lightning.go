@@ -0,0 +1,201 @@
+package bark
+
+import "fmt"
+
+// ReceivedPaymentPreimage returns the preimage of the inbound lightning
+// payment with the given hash once it has been settled. It fails with
+// ErrErrorUnknownPayment if the wallet has no invoice for the hash and with
+// ErrErrorPaymentNotSettled if the preimage has not been revealed yet.
+func (_self *Wallet) ReceivedPaymentPreimage(paymentHash PaymentHash) (string, error) {
+	receive, err := _self.LookupInvoice(paymentHash)
+	if err != nil {
+		return "", err
+	}
+	if receive == nil {
+		return "", fmt.Errorf("%w: %s", ErrErrorUnknownPayment, paymentHash)
+	}
+	if receive.PreimageRevealedAt == nil {
+		return "", fmt.Errorf("%w: %s", ErrErrorPaymentNotSettled, paymentHash)
+	}
+	return receive.PaymentPreimage, nil
+}
+
+// Bolt11Invoice creates an invoice to receive amountSats over lightning. It
+// fails with ErrErrorAmountExceedsMaxVtxo if the ASP caps vtxos below
+// amountSats.
+func (_self *Wallet) Bolt11Invoice(amountSats uint64) (invoice Bolt11Invoice, err error) {
+	defer logCall("Bolt11Invoice", "amount_sats", amountSats)(&err)
+	if err := _self.checkMaxVtxoAmount(amountSats); err != nil {
+		return "", err
+	}
+	return _self.bolt11InvoiceNative(amountSats)
+}
+
+// PayBolt11 pays invoice and returns the payment preimage. amountSats must be
+// given for invoices without an amount and is counted against the daily send
+// limit, as is the invoice amount otherwise.
+func (_self *Wallet) PayBolt11(invoice Bolt11Invoice, amountSats *uint64) (preimage string, err error) {
+	defer logCall("PayBolt11", "amount_sats", optionalAmount(amountSats))(&err)
+	pay, _, err := _self.preparePayBolt11(invoice, amountSats)
+	if err != nil {
+		return "", err
+	}
+	return pay()
+}
+
+// preparePayBolt11 runs the checks PayBolt11 makes before handing the
+// payment to the native library, and reserves the amount against the daily
+// send limit. If they fail nothing has been locked for the payment. pay
+// makes the payment, releasing the reservation if it fails; release gives
+// up the payment without making it.
+//
+// beforeAsp is called here rather than in payBolt11Native so that its
+// failures are among the checks.
+func (_self *Wallet) preparePayBolt11(invoice Bolt11Invoice, amountSats *uint64) (pay func() (string, error), release func(), err error) {
+	var amount uint64
+	if amountSats != nil {
+		amount = *amountSats
+	} else {
+		msat, ok, err := bolt11AmountMsat(invoice)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			amount = (msat + 999) / 1000
+		}
+	}
+	release, err = _self.reserveSend(amount)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := _self.beforeAsp(); err != nil {
+		release()
+		return nil, nil, err
+	}
+	pay = func() (string, error) {
+		preimage, err := _self.payBolt11Native(invoice, amountSats)
+		if err != nil {
+			release()
+		}
+		return preimage, err
+	}
+	return pay, release, nil
+}
+
+// payReclaimAttempts bounds how many maintenance runs PayBolt11Atomic makes
+// to reclaim the funds of a failed payment.
+const payReclaimAttempts = 3
+
+// PaymentResult is a lightning payment made by PayBolt11Atomic.
+type PaymentResult struct {
+	Preimage  string
+	AmountSat uint64
+}
+
+// PayBolt11Atomic pays invoice like PayBolt11, but if the payment fails it
+// does not return until the funds locked for it are back in the spendable
+// balance, so the caller never sees a half-finished payment. It reclaims
+// them by running Maintenance, which revokes the failed payment, until the
+// pending lightning send amount is back to what it was before the payment.
+// Errors from PayBolt11's own checks, such as an invalid invoice or the
+// daily send limit, are returned as they are, since no funds were locked.
+//
+// A failed payment therefore takes at least one extra maintenance run,
+// which talks to the ASP and can take seconds. If the funds are still
+// pending after payReclaimAttempts runs, the error wraps
+// ErrErrorPaymentNotReclaimed as well as the payment error.
+func (_self *Wallet) PayBolt11Atomic(invoice Bolt11Invoice, amountSats *uint64) (result PaymentResult, err error) {
+	defer logCall("PayBolt11Atomic", "amount_sats", optionalAmount(amountSats))(&err)
+	pay, release, err := _self.preparePayBolt11(invoice, amountSats)
+	if err != nil {
+		return PaymentResult{}, err
+	}
+	before, err := _self.WalletBalance()
+	if err != nil {
+		release()
+		return PaymentResult{}, err
+	}
+	preimage, payErr := pay()
+	if payErr == nil {
+		result = PaymentResult{Preimage: preimage}
+		if amountSats != nil {
+			result.AmountSat = *amountSats
+		} else if msat, ok, err := bolt11AmountMsat(invoice); err == nil && ok {
+			result.AmountSat = (msat + 999) / 1000
+		}
+		return result, nil
+	}
+
+	var reclaimErr error
+	for range payReclaimAttempts {
+		if reclaimErr = _self.Maintenance(); reclaimErr != nil {
+			continue
+		}
+		var balance WalletBalance
+		balance, reclaimErr = _self.WalletBalance()
+		if reclaimErr != nil {
+			continue
+		}
+		if balance.PendingLightningSendSat <= before.PendingLightningSendSat {
+			return PaymentResult{}, payErr
+		}
+		reclaimErr = fmt.Errorf("%d sat still pending, %d sat before the payment",
+			balance.PendingLightningSendSat, before.PendingLightningSendSat)
+	}
+	return PaymentResult{}, fmt.Errorf("%w: %w (reclaiming: %v)", ErrErrorPaymentNotReclaimed, payErr, reclaimErr)
+}
+
+// Bolt11PaymentResult is a lightning payment made by PayBolt11Detailed.
+type Bolt11PaymentResult struct {
+	Preimage    string
+	PaymentHash PaymentHash
+	AmountSat   uint64
+	// FeePaidSat is the fee of the payment's lightning send movement, or
+	// zero if the movement could not be found.
+	FeePaidSat uint64
+}
+
+// PayBolt11Detailed pays invoice like PayBolt11 and returns the payment's
+// hash, amount and fee along with the preimage.
+func (_self *Wallet) PayBolt11Detailed(invoice Bolt11Invoice, amountSats *uint64) (Bolt11PaymentResult, error) {
+	result, err := newBolt11PaymentResult(invoice, amountSats)
+	if err != nil {
+		return Bolt11PaymentResult{}, err
+	}
+
+	before, err := _self.Movements()
+	if err != nil {
+		return Bolt11PaymentResult{}, err
+	}
+	if result.Preimage, err = _self.PayBolt11(invoice, amountSats); err != nil {
+		return Bolt11PaymentResult{}, err
+	}
+	// The payment has gone through; a failure to read the fee is not an
+	// error.
+	if after, err := _self.Movements(); err == nil {
+		for _, movement := range newMovements(before, after) {
+			if movement.Kind == MovementKindLightningSend {
+				result.FeePaidSat = movement.FeesSat
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// newBolt11PaymentResult fills in what PayBolt11Detailed reports about
+// invoice before it is paid: the payment hash from the invoice, and the
+// amount given or else the invoice's amount, rounded up to whole sats.
+func newBolt11PaymentResult(invoice Bolt11Invoice, amountSats *uint64) (Bolt11PaymentResult, error) {
+	hash, err := bolt11PaymentHash(invoice)
+	if err != nil {
+		return Bolt11PaymentResult{}, err
+	}
+	result := Bolt11PaymentResult{PaymentHash: hash}
+	if amountSats != nil {
+		result.AmountSat = *amountSats
+	} else if msat, ok, err := bolt11AmountMsat(invoice); err == nil && ok {
+		result.AmountSat = (msat + 999) / 1000
+	}
+	return result, nil
+}
@@ -0,0 +1,97 @@
+package bark
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// Invoices from the examples in BOLT 11. Both pay to the hash 0001...0102.
+const (
+	testInvoiceNoAmount = "lnbc1pvjluezsp5zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zygspp5qqqsyqcyq5rqwzqfqqqsyqcyq5rqwzqfqqqsyqcyq5rqwzqfqypqdpl2pkx2ctnv5sxxmmwwd5kgetjypeh2ursdae8g6twvus8g6rfwvs8qun0dfjkxaq9qrsgq357wnc5r2ueh7ck6q93dj32dlqnls087fxdwk8qakdyafkq3yap9us6v52vjjsrvywa6rt52cm9r9zqt8r2t7mlcwspyetp5h2tztugp9lfyql"
+	testInvoice2500u    = "lnbc2500u1pvjluezsp5zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zygspp5qqqsyqcyq5rqwzqfqqqsyqcyq5rqwzqfqqqsyqcyq5rqwzqfqypqdq5xysxxatsyp3k7enxv4jsxqzpu9qrsgquk0rl77nj30yxdy8j9vdx85fkpmdla2087ne0xh8nhedh8w27kyke0lp53ut353s06fv3qfegext0eh0ymjpf39tuven09sam30g4vgpfna3rh"
+	testInvoiceHash     = "0001020304050607080900010203040506070809000102030405060708090102"
+)
+
+func TestBolt11PaymentResultHash(t *testing.T) {
+	amount := uint64(1_000)
+	tests := []struct {
+		name       string
+		invoice    Bolt11Invoice
+		amountSats *uint64
+		wantAmount uint64
+	}{
+		{"invoice amount", testInvoice2500u, nil, 250_000},
+		{"given amount", testInvoiceNoAmount, &amount, 1_000},
+		{"no amount", testInvoiceNoAmount, nil, 0},
+		{"lightning URI", "lightning:" + testInvoice2500u, nil, 250_000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newBolt11PaymentResult(tt.invoice, tt.amountSats)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := bolt11PaymentHash(tt.invoice)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.PaymentHash != decoded || decoded != testInvoiceHash {
+				t.Errorf("PaymentHash = %s, decoded %s, want %s", result.PaymentHash, decoded, testInvoiceHash)
+			}
+			if result.AmountSat != tt.wantAmount {
+				t.Errorf("AmountSat = %d, want %d", result.AmountSat, tt.wantAmount)
+			}
+		})
+	}
+}
+
+func TestBolt11PaymentResultInvalidInvoice(t *testing.T) {
+	tampered := testInvoice2500u[:len(testInvoice2500u)-1] + "q"
+	for _, invoice := range []Bolt11Invoice{"", "lnbc1", tampered} {
+		if _, err := newBolt11PaymentResult(invoice, nil); !errors.Is(err, ErrErrorInvalidBolt11Invoice) {
+			t.Errorf("newBolt11PaymentResult(%q) error = %v, want ErrErrorInvalidBolt11Invoice", invoice, err)
+		}
+	}
+}
+
+// TestPayBolt11AtomicChecks covers failures before the native call, which
+// PayBolt11Atomic must return as they are: the fake wallet would fail the
+// test on any native call, including the reclaiming maintenance.
+func TestPayBolt11AtomicChecks(t *testing.T) {
+	amount := uint64(1_000)
+	tests := []struct {
+		name       string
+		invoice    Bolt11Invoice
+		amountSats *uint64
+		limit      uint64
+		mismatch   bool
+		want       error
+	}{
+		{"invalid invoice", "notaninvoice", nil, 0, false, ErrErrorInvalidBolt11Invoice},
+		{"daily limit", testInvoice2500u, nil, amount, false, ErrErrorSendLimitExceeded},
+		{"pin mismatch", testInvoiceNoAmount, &amount, 2 * amount, true, ErrErrorAspPubkeyMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wallet, _ := newTestWallet(t)
+			withTestMeta(t, wallet)
+			if err := wallet.SetDailySendLimit(tt.limit); err != nil {
+				t.Fatal(err)
+			}
+			if tt.mismatch {
+				mismatch := fmt.Errorf("%w: test", ErrErrorAspPubkeyMismatch)
+				wallet.state.aspMismatch.Store(&mismatch)
+				wallet.state.aspChecked.Store(true)
+			}
+
+			_, err := wallet.PayBolt11Atomic(tt.invoice, tt.amountSats)
+			if !errors.Is(err, tt.want) || errors.Is(err, ErrErrorPaymentNotReclaimed) {
+				t.Fatalf("got %v, want %v as is", err, tt.want)
+			}
+			if remaining, _, err := wallet.DailySendRemaining(); err != nil || remaining != tt.limit {
+				t.Errorf("remaining = %d, %v; want the reservation released, %d", remaining, err, tt.limit)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package bark
+
+import (
+	"fmt"
+	"time"
+)
+
+// sendLimitWindow is the rolling window SetDailySendLimit applies to.
+const sendLimitWindow = 24 * time.Hour
+
+// sendRecord is an amount counted against the daily send limit.
+type sendRecord struct {
+	At        time.Time `json:"at"`
+	AmountSat uint64    `json:"amount_sat"`
+}
+
+// SetDailySendLimit caps the total that Send, SendOnchain and PayBolt11 may
+// send in any rolling 24 hour window. A payment that would exceed it fails
+// with ErrErrorSendLimitExceeded before the native library is called. Zero
+// removes the limit, which is the default. The limit and the amounts sent
+// are persisted with the wallet.
+func (_self *Wallet) SetDailySendLimit(sats uint64) error {
+	store, err := _self.metaStore()
+	if err != nil {
+		return err
+	}
+	return store.update(func(meta *walletMeta) error {
+		meta.DailySendLimitSat = sats
+		return nil
+	})
+}
+
+// DailySendRemaining returns how much can still be sent in the current
+// window, and false if no limit is set.
+func (_self *Wallet) DailySendRemaining() (uint64, bool, error) {
+	store, err := _self.metaStore()
+	if err != nil {
+		return 0, false, err
+	}
+	var (
+		remaining uint64
+		limited   bool
+	)
+	store.view(func(meta *walletMeta) {
+		limited = meta.DailySendLimitSat > 0
+		remaining = sendAllowance(meta, time.Now())
+	})
+	return remaining, limited, nil
+}
+
+// checkSendAllowance fails with ErrErrorSendLimitExceeded if sending
+// amountSats now would exceed the daily send limit. Unlike reserveSend it
+// does not count the amount. Without metadata the limit cannot be checked,
+// so it fails rather than let the send through.
+func (_self *Wallet) checkSendAllowance(amountSats uint64) error {
+	remaining, limited, err := _self.DailySendRemaining()
+	if err != nil {
+		return err
+	}
+	if !limited || amountSats <= remaining {
+		return nil
+	}
+	return fmt.Errorf("%w: sending %d sat, %d sat of the daily limit remaining",
+		ErrErrorSendLimitExceeded, amountSats, remaining)
+}
+
+// reserveSend counts amountSats against the daily send limit, if one is
+// set. Like checkSendAllowance it fails without metadata. The returned
+// release undoes the reservation and must be called if the payment fails.
+func (_self *Wallet) reserveSend(amountSats uint64) (release func(), err error) {
+	noop := func() {}
+	store, err := _self.metaStore()
+	if err != nil {
+		return noop, err
+	}
+	record := sendRecord{At: time.Now().UTC(), AmountSat: amountSats}
+	reserved := false
+	err = store.update(func(meta *walletMeta) error {
+		if meta.DailySendLimitSat == 0 {
+			meta.SendLog = nil
+			return nil
+		}
+		meta.SendLog = pruneSendLog(meta.SendLog, record.At)
+		if remaining := sendAllowance(meta, record.At); amountSats > remaining {
+			return fmt.Errorf("%w: sending %d sat, %d sat of the daily limit of %d sat remaining",
+				ErrErrorSendLimitExceeded, amountSats, remaining, meta.DailySendLimitSat)
+		}
+		meta.SendLog = append(meta.SendLog, record)
+		reserved = true
+		return nil
+	})
+	if err != nil || !reserved {
+		return noop, err
+	}
+	return func() {
+		store.update(func(meta *walletMeta) error {
+			for i, r := range meta.SendLog {
+				if r.At.Equal(record.At) && r.AmountSat == record.AmountSat {
+					meta.SendLog = append(meta.SendLog[:i], meta.SendLog[i+1:]...)
+					break
+				}
+			}
+			return nil
+		})
+	}, nil
+}
+
+// sendAllowance is what is left of the daily limit at now. It is zero if no
+// limit is set.
+func sendAllowance(meta *walletMeta, now time.Time) uint64 {
+	var spent uint64
+	for _, r := range pruneSendLog(meta.SendLog, now) {
+		spent += r.AmountSat
+	}
+	if spent >= meta.DailySendLimitSat {
+		return 0
+	}
+	return meta.DailySendLimitSat - spent
+}
+
+// pruneSendLog drops the records that fell out of the window ending at now.
+func pruneSendLog(log []sendRecord, now time.Time) []sendRecord {
+	cutoff := now.Add(-sendLimitWindow)
+	kept := log[:0:0]
+	for _, r := range log {
+		if r.At.After(cutoff) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
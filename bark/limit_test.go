@@ -0,0 +1,40 @@
+package bark
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckSendAllowance(t *testing.T) {
+	tests := []struct {
+		name   string
+		meta   bool
+		limit  uint64
+		amount uint64
+		want   error
+	}{
+		{"no metadata", false, 0, 1000, ErrErrorWalletMetadataUnavailable},
+		{"no limit", true, 0, 1000, nil},
+		{"within limit", true, 1000, 1000, nil},
+		{"over limit", true, 1000, 1001, ErrErrorSendLimitExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wallet, _ := newTestWallet(t)
+			if tt.meta {
+				withTestMeta(t, wallet)
+				if err := wallet.SetDailySendLimit(tt.limit); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := wallet.checkSendAllowance(tt.amount); !errors.Is(err, tt.want) {
+				t.Errorf("checkSendAllowance: got %v, want %v", err, tt.want)
+			}
+			release, err := wallet.reserveSend(tt.amount)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("reserveSend: got %v, want %v", err, tt.want)
+			}
+			release()
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package bark
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// genesisHashes are the genesis block hashes of the supported networks.
+var genesisHashes = map[Network]string{
+	NetworkBitcoin: "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f",
+	NetworkTestnet: "000000000933ea01ad0ee984209779baaec3ced90fa3f408719526f8d77f4943",
+	NetworkSignet:  "00000008819873e925422c1ff0f99f7cc9bbb232af63a077a480a3633bee1ef6",
+	NetworkRegtest: "0f9188f13cb7b2c71f2a335e3a4fc328bf5beb436012afca590b1a11466e2206",
+}
+
+// ValidateConfigLive checks cfg like Config.Validate and then against the
+// live endpoints: Esplora must answer and serve the chain of cfg.Network,
+// and the ASP must accept a connection. It fails with
+// ErrErrorEndpointUnreachable or ErrErrorNetworkMismatch.
+//
+// The ASP's protocol version cannot be checked without the native library,
+// so an ASP that accepts connections but speaks an incompatible version
+// still passes; CreateWallet reports that case.
+func ValidateConfigLive(ctx context.Context, cfg Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	want, ok := genesisHashes[cfg.Network]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrErrorInvalidNetwork, cfg.Network)
+	}
+
+	esplora := newEsploraClient(cfg.EsploraAddress)
+	genesis, err := esplora.get(ctx, "/block-height/0")
+	if err != nil {
+		return fmt.Errorf("%w: esplora: %w", ErrErrorEndpointUnreachable, err)
+	}
+	genesis = strings.TrimSpace(genesis)
+	if genesis != want {
+		return fmt.Errorf("%w: esplora serves the chain with genesis %s, not %s", ErrErrorNetworkMismatch, genesis, cfg.Network)
+	}
+
+	asp, err := url.Parse(cfg.AspAddress)
+	if err != nil {
+		return fmt.Errorf("%w: AspAddress: %v", ErrErrorInvalidURL, err)
+	}
+	port := asp.Port()
+	if port == "" {
+		port = asp.Scheme
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(asp.Hostname(), port))
+	if err != nil {
+		return fmt.Errorf("%w: asp: %v", ErrErrorEndpointUnreachable, err)
+	}
+	return conn.Close()
+}
@@ -0,0 +1,78 @@
+package bark
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// lnurlPayParams is the part of an LNURL-pay response the Go layer uses.
+type lnurlPayParams struct {
+	Tag         string `json:"tag"`
+	Callback    string `json:"callback"`
+	MinSendable uint64 `json:"minSendable"`
+	MaxSendable uint64 `json:"maxSendable"`
+	Status      string `json:"status"`
+	Reason      string `json:"reason"`
+}
+
+// lnurlPayURL returns the URL to fetch LNURL-pay parameters from, for either
+// a bech32 encoded LNURL or a lightning address.
+func lnurlPayURL(destination string) (string, error) {
+	if user, domain, ok := strings.Cut(destination, "@"); ok {
+		if user == "" || domain == "" || strings.ContainsAny(domain, "/@") {
+			return "", fmt.Errorf("%w: bad lightning address %q", ErrErrorInvalidLnurl, destination)
+		}
+		return "https://" + domain + "/.well-known/lnurlp/" + url.PathEscape(user), nil
+	}
+	hrp, data, _, err := bech32Decode(destination)
+	if err != nil || hrp != "lnurl" {
+		return "", fmt.Errorf("%w: %q is not an LNURL", ErrErrorInvalidLnurl, destination)
+	}
+	decoded, err := convertBits(data, 5, 8, false)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrErrorInvalidLnurl, err)
+	}
+	return string(decoded), nil
+}
+
+// fetchLnurlPay fetches the LNURL-pay parameters for destination.
+func fetchLnurlPay(ctx context.Context, destination string) (lnurlPayParams, error) {
+	target, err := lnurlPayURL(destination)
+	if err != nil {
+		return lnurlPayParams{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return lnurlPayParams{}, fmt.Errorf("%w: %v", ErrErrorInvalidLnurl, err)
+	}
+	client := &http.Client{Timeout: esploraTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return lnurlPayParams{}, fmt.Errorf("%w: %v", ErrErrorLnurlFailed, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return lnurlPayParams{}, fmt.Errorf("%w: %v", ErrErrorLnurlFailed, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return lnurlPayParams{}, fmt.Errorf("%w: %s: %s", ErrErrorLnurlFailed, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var params lnurlPayParams
+	if err := json.Unmarshal(body, &params); err != nil {
+		return lnurlPayParams{}, fmt.Errorf("%w: decoding response: %v", ErrErrorLnurlFailed, err)
+	}
+	if params.Status == "ERROR" {
+		return lnurlPayParams{}, fmt.Errorf("%w: %s", ErrErrorLnurlFailed, params.Reason)
+	}
+	if params.Tag != "payRequest" {
+		return lnurlPayParams{}, fmt.Errorf("%w: not a pay request (tag %q)", ErrErrorLnurlFailed, params.Tag)
+	}
+	return params, nil
+}
@@ -0,0 +1,131 @@
+package bark
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Logger receives the Go layer's log output. See SetLogger.
+type Logger interface {
+	Debugf(format string, args ...any)
+	Errorf(format string, args ...any)
+}
+
+var logger atomic.Pointer[Logger]
+
+// SetLogger makes every Wallet method log, to l, when it is called, with its
+// amounts and other non-secret arguments, and when it returns, with its
+// duration and any error. Calls that succeed are logged with Debugf and
+// failures with Errorf. Mnemonics, addresses and invoices are never logged.
+// A nil l turns logging off, which is the default. A library mismatch
+// tolerated under CompatibilityCheckEnv is reported to the first l set.
+func SetLogger(l Logger) {
+	if l == nil {
+		logger.Store(nil)
+		return
+	}
+	logger.Store(&l)
+	reportCompatibility(l)
+}
+
+// logCall logs the start of a call to name with the given key-value args and
+// returns a function that logs its end, for use as
+//
+//	defer logCall("Send", "amount_sats", amountSats)(&err)
+//
+// The returned function takes nil for methods that cannot fail.
+func logCall(name string, args ...any) func(err *error) {
+	l := logger.Load()
+	if l == nil {
+		return func(*error) {}
+	}
+	var b strings.Builder
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+	}
+	(*l).Debugf("bark: %s started%s", name, b.String())
+	started := time.Now()
+	return func(err *error) {
+		took := time.Since(started)
+		if err != nil && *err != nil {
+			(*l).Errorf("bark: %s failed after %s: %v", name, took, *err)
+			return
+		}
+		(*l).Debugf("bark: %s done in %s", name, took)
+	}
+}
+
+// The wallet methods below have no Go-layer logic of their own and are
+// wrapped only to be logged.
+
+func (_self *Wallet) ArkInfo() (info ArkInfo, err error) {
+	defer logCall("ArkInfo")(&err)
+	return _self.arkInfoNative()
+}
+
+func (_self *Wallet) BoardAll() (err error) {
+	defer logCall("BoardAll")(&err)
+	return _self.boardAllNative()
+}
+
+func (_self *Wallet) ClaimBolt11Payment(invoice Bolt11Invoice) (err error) {
+	defer logCall("ClaimBolt11Payment")(&err)
+	return _self.claimBolt11PaymentNative(invoice)
+}
+
+func (_self *Wallet) ExitAll() (err error) {
+	defer logCall("ExitAll")(&err)
+	return _self.exitAllNative()
+}
+
+func (_self *Wallet) ExitStatus() (status ExitStatus, err error) {
+	defer logCall("ExitStatus")(&err)
+	return _self.exitStatusNative()
+}
+
+func (_self *Wallet) LookupInvoice(paymentHash PaymentHash) (receive *LightningReceive, err error) {
+	defer logCall("LookupInvoice", "payment_hash", paymentHash)(&err)
+	return _self.lookupInvoiceNative(paymentHash)
+}
+
+func (_self *Wallet) NewAddress() (address BarkAddress, err error) {
+	defer logCall("NewAddress")(&err)
+	return _self.newAddressNative()
+}
+
+func (_self *Wallet) OffboardAll() (err error) {
+	defer logCall("OffboardAll")(&err)
+	return _self.offboardAllNative()
+}
+
+func (_self *Wallet) OnchainAddress() (address string, err error) {
+	defer logCall("OnchainAddress")(&err)
+	return _self.onchainAddressNative()
+}
+
+func (_self *Wallet) OnchainBalance() (balance OnchainBalance, err error) {
+	defer logCall("OnchainBalance")(&err)
+	return _self.onchainBalanceNative()
+}
+
+func (_self *Wallet) OnchainTransactions() []OnchainTransaction {
+	defer logCall("OnchainTransactions")(nil)
+	return _self.onchainTransactionsNative()
+}
+
+func (_self *Wallet) RefreshAll() (err error) {
+	defer logCall("RefreshAll")(&err)
+	return _self.refreshAllNative()
+}
+
+func (_self *Wallet) Utxos() []Utxo {
+	defer logCall("Utxos")(nil)
+	return _self.utxosNative()
+}
+
+func (_self *Wallet) WalletBalance() (balance WalletBalance, err error) {
+	defer logCall("WalletBalance")(&err)
+	return _self.walletBalanceNative()
+}
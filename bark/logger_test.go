@@ -0,0 +1,82 @@
+package bark
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// captureLogger records every line logged through it.
+type captureLogger struct {
+	mu     sync.Mutex
+	debugs []string
+	errors []string
+}
+
+func (l *captureLogger) Debugf(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.debugs = append(l.debugs, fmt.Sprintf(format, args...))
+}
+
+func (l *captureLogger) Errorf(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errors = append(l.errors, fmt.Sprintf(format, args...))
+}
+
+// setTestLogger installs a captureLogger for the rest of the test.
+func setTestLogger(t *testing.T) *captureLogger {
+	l := &captureLogger{}
+	SetLogger(l)
+	t.Cleanup(func() { SetLogger(nil) })
+	return l
+}
+
+func TestSendIsLogged(t *testing.T) {
+	l := setTestLogger(t)
+	wallet, _ := newTestWallet(t)
+	withTestMeta(t, wallet)
+	// A destination outside the allowlist makes Send fail before it reaches
+	// the native library.
+	if err := wallet.SetSendAllowlist([]string{testArkAddress(1)}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := wallet.Send(testArkAddress(2), 1234)
+	if !errors.Is(err, ErrErrorDestinationNotAllowed) {
+		t.Fatalf("Send error = %v, want ErrErrorDestinationNotAllowed", err)
+	}
+
+	if len(l.debugs) != 1 || l.debugs[0] != "bark: Send started amount_sats=1234" {
+		t.Errorf("debug lines = %q, want the start of Send with its amount", l.debugs)
+	}
+	if len(l.errors) != 1 || !strings.HasPrefix(l.errors[0], "bark: Send failed after ") ||
+		!strings.Contains(l.errors[0], "ErrorDestinationNotAllowed") {
+		t.Errorf("error lines = %q, want the failure of Send", l.errors)
+	}
+}
+
+func TestLogCallSuccess(t *testing.T) {
+	l := setTestLogger(t)
+	func() (err error) {
+		defer logCall("Send", "amount_sats", uint64(5000))(&err)
+		return nil
+	}()
+	if len(l.debugs) != 2 || l.debugs[0] != "bark: Send started amount_sats=5000" ||
+		!strings.HasPrefix(l.debugs[1], "bark: Send done in ") {
+		t.Errorf("debug lines = %q, want start and end of Send", l.debugs)
+	}
+	if len(l.errors) != 0 {
+		t.Errorf("error lines = %q, want none", l.errors)
+	}
+}
+
+func TestLogCallWithoutLogger(t *testing.T) {
+	SetLogger(nil)
+	var err error
+	logCall("Send", "amount_sats", 1)(&err)
+	logCall("IsAlive")(nil)
+}
@@ -0,0 +1,158 @@
+package bark
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testArkInfo() ArkInfo {
+	max := uint64(10_000_000)
+	return ArkInfo{
+		Network:           NetworkSignet,
+		AspPubkey:         "02" + strings.Repeat("ab", 32),
+		RoundIntervalSec:  30,
+		NbRoundNonces:     64,
+		VtxoExitDelta:     144,
+		VtxoExpiryDelta:   4032,
+		MaxVtxoAmountSats: &max,
+	}
+}
+
+func testLowerMovements(n int) []Movement {
+	movements := make([]Movement, n)
+	for i := range movements {
+		movements[i] = Movement{
+			Id:                uint32(i + 1),
+			Kind:              MovementKindArkoorReceive,
+			AmountReceivedSat: uint64(1_000 * (i + 1)),
+			FeesSat:           uint64(i),
+			CreatedAt:         "2024-05-01 10:00:00",
+		}
+	}
+	return movements
+}
+
+// lowerUnpooled lowers value the way LowerIntoRustBuffer did before its
+// buffers were pooled, for comparison in benchmarks.
+func lowerUnpooled[T any](writer BufWriter[T], value T) GoRustBuffer {
+	return GoRustBuffer{inner: bytesToRustBuffer(writtenBytes(writer, value))}
+}
+
+// lowerReadAll lowers value the way LowerIntoRustBuffer did before it
+// handed the pooled buffer's bytes over directly: they were first copied
+// out with io.ReadAll.
+func lowerReadAll[T any](writer BufWriter[T], value T) GoRustBuffer {
+	buffer := lowerBufferPool.Get().(*bytes.Buffer)
+	defer func() {
+		buffer.Reset()
+		lowerBufferPool.Put(buffer)
+	}()
+	writer.Write(buffer, value)
+	written, err := io.ReadAll(buffer)
+	if err != nil {
+		panic(err)
+	}
+	return GoRustBuffer{inner: bytesToRustBuffer(written)}
+}
+
+// writtenBytes returns what writer writes for value, as the io.ReadAll
+// path lowered it.
+func writtenBytes[T any](writer BufWriter[T], value T) []byte {
+	var buffer bytes.Buffer
+	writer.Write(&buffer, value)
+	written, err := io.ReadAll(&buffer)
+	if err != nil {
+		panic(err)
+	}
+	return written
+}
+
+func checkLowered[T any](t *testing.T, converter interface {
+	BufWriter[T]
+	BufReader[T]
+}, value T) {
+	t.Helper()
+	want := writtenBytes[T](converter, value)
+	lowered := GoRustBuffer{inner: LowerIntoRustBuffer[T](converter, value)}
+	if got := lowered.ToGoBytes(); !bytes.Equal(got, want) {
+		lowered.Free()
+		t.Fatalf("lowered bytes = %x, want %x", got, want)
+	}
+	if lifted := LiftFromRustBuffer[T](converter, lowered); !reflect.DeepEqual(lifted, value) {
+		t.Errorf("lifted = %+v, want %+v", lifted, value)
+	}
+}
+
+func TestLowerIntoRustBufferMatchesReadAll(t *testing.T) {
+	t.Run("Config", func(t *testing.T) {
+		config := validConfig()
+		checkLowered[Config](t, FfiConverterConfigINSTANCE, config)
+	})
+	t.Run("ArkInfo", func(t *testing.T) {
+		checkLowered[ArkInfo](t, FfiConverterArkInfoINSTANCE, testArkInfo())
+		info := testArkInfo()
+		info.MaxVtxoAmountSats = nil
+		checkLowered[ArkInfo](t, FfiConverterArkInfoINSTANCE, info)
+	})
+	t.Run("Movements", func(t *testing.T) {
+		checkLowered[[]Movement](t, FfiConverterSequenceMovementINSTANCE, testLowerMovements(100))
+		checkLowered[[]Movement](t, FfiConverterSequenceMovementINSTANCE, []Movement{})
+	})
+	t.Run("Vtxos", func(t *testing.T) {
+		vtxos := []Vtxo{
+			{Point: OutPoint{Txid: "aa", Vout: 1}, AmountSat: 5_000, ExpiryHeight: 900_000},
+			{Point: OutPoint{Txid: "bb", Vout: 0}, AmountSat: 7_000, IsArkoor: true},
+		}
+		checkLowered[[]Vtxo](t, FfiConverterSequenceVtxoINSTANCE, vtxos)
+	})
+	t.Run("Utxos", func(t *testing.T) {
+		height := uint32(800_000)
+		utxos := []Utxo{
+			UtxoLocal{Outpoint: OutPoint{Txid: "aa", Vout: 0}, AmountSat: 10_000, ConfirmationHeight: &height},
+			UtxoExit{Vtxo: Vtxo{AmountSat: 25_000}, Height: 800_100},
+		}
+		checkLowered[[]Utxo](t, FfiConverterSequenceUtxoINSTANCE, utxos)
+	})
+}
+
+func benchmarkLower[T any](b *testing.B, writer BufWriter[T], value T) {
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			GoRustBuffer{inner: LowerIntoRustBuffer(writer, value)}.Free()
+		}
+	})
+	b.Run("readall", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			lowerReadAll(writer, value).Free()
+		}
+	})
+	b.Run("unpooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			lowerUnpooled(writer, value).Free()
+		}
+	})
+}
+
+// BenchmarkLowerIntoRustBuffer compares LowerIntoRustBuffer ("pooled")
+// with the paths it replaced: copying the pooled buffer's bytes out with
+// io.ReadAll first ("readall"), and a fresh buffer per call ("unpooled").
+func BenchmarkLowerIntoRustBuffer(b *testing.B) {
+	b.Run("Config", func(b *testing.B) {
+		benchmarkLower[Config](b, FfiConverterConfigINSTANCE, validConfig())
+	})
+	b.Run("ArkInfo", func(b *testing.B) {
+		benchmarkLower[ArkInfo](b, FfiConverterArkInfoINSTANCE, testArkInfo())
+	})
+	for _, n := range []int{10, 1_000} {
+		b.Run(fmt.Sprintf("Movements/%d", n), func(b *testing.B) {
+			benchmarkLower[[]Movement](b, FfiConverterSequenceMovementINSTANCE, testLowerMovements(n))
+		})
+	}
+}
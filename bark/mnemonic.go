@@ -0,0 +1,47 @@
+package bark
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// GenerateMnemonic returns a new random BIP39 mnemonic of wordCount words,
+// which must be 12, 15, 18, 21 or 24. Entropy comes from crypto/rand.
+func GenerateMnemonic(wordCount int) (string, error) {
+	if wordCount < 12 || wordCount > 24 || wordCount%3 != 0 {
+		return "", fmt.Errorf("%w: word count %d, want 12, 15, 18, 21 or 24", ErrErrorInvalidMnemonic, wordCount)
+	}
+	// Every 3 words carry 32 bits of entropy and 1 bit of checksum.
+	entropy := make([]byte, wordCount/3*4)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return entropyToMnemonic(entropy)
+}
+
+// DeterministicMnemonic returns a valid 12-word BIP39 mnemonic derived from
+// seed. The same seed always gives the same mnemonic.
+//
+// For tests and examples only: the mnemonic has at most 64 bits of entropy
+// and anyone who knows or guesses seed can spend from the wallet.
+func DeterministicMnemonic(seed uint64) string {
+	var input [8]byte
+	binary.BigEndian.PutUint64(input[:], seed)
+	hash := sha256.Sum256(append([]byte("bark deterministic mnemonic"), input[:]...))
+
+	mnemonic, err := entropyToMnemonic(hash[:16])
+	if err != nil {
+		panic(err)
+	}
+	return mnemonic
+}
+
+// ValidateMnemonic checks that mnemonic is a valid BIP39 English mnemonic:
+// a supported word count, every word in the wordlist and a matching
+// checksum. Failures wrap ErrErrorInvalidMnemonic, as CreateWallet's do.
+func ValidateMnemonic(mnemonic string) error {
+	_, err := mnemonicToEntropy(mnemonic)
+	return err
+}
@@ -0,0 +1,98 @@
+package bark
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerateMnemonic(t *testing.T) {
+	for _, words := range []int{12, 15, 18, 21, 24} {
+		mnemonic, err := GenerateMnemonic(words)
+		if err != nil {
+			t.Fatalf("GenerateMnemonic(%d): %v", words, err)
+		}
+		if got := len(strings.Fields(mnemonic)); got != words {
+			t.Errorf("GenerateMnemonic(%d) has %d words", words, got)
+		}
+		if err := ValidateMnemonic(mnemonic); err != nil {
+			t.Errorf("ValidateMnemonic(GenerateMnemonic(%d)): %v", words, err)
+		}
+	}
+}
+
+func TestGenerateMnemonicIsRandom(t *testing.T) {
+	a, err := GenerateMnemonic(12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := GenerateMnemonic(12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a == b {
+		t.Errorf("two calls returned the same mnemonic %q", a)
+	}
+}
+
+func TestGenerateMnemonicInvalidWordCount(t *testing.T) {
+	for _, words := range []int{-12, 0, 11, 13, 25, 27} {
+		if _, err := GenerateMnemonic(words); !errors.Is(err, ErrErrorInvalidMnemonic) {
+			t.Errorf("GenerateMnemonic(%d) error = %v, want ErrErrorInvalidMnemonic", words, err)
+		}
+	}
+}
+
+func TestValidateMnemonic(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+		valid    bool
+	}{
+		{
+			name:     "known-good 12 words",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			valid:    true,
+		},
+		{
+			name:     "known-good 12 words with mixed entropy",
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+			valid:    true,
+		},
+		{
+			name:     "known-good 24 words",
+			mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+			valid:    true,
+		},
+		{
+			name:     "wrong checksum",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		},
+		{
+			name:     "word not in the wordlist",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon notaword",
+		},
+		{
+			name:     "wrong word count",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		},
+		{
+			name:     "empty",
+			mnemonic: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMnemonic(tt.mnemonic)
+			if tt.valid {
+				if err != nil {
+					t.Errorf("ValidateMnemonic: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrErrorInvalidMnemonic) {
+				t.Errorf("ValidateMnemonic error = %v, want ErrErrorInvalidMnemonic", err)
+			}
+		})
+	}
+}
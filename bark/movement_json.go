@@ -0,0 +1,64 @@
+package bark
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// movementJSON is the JSON form of a Movement.
+type movementJSON struct {
+	Id                uint32        `json:"id"`
+	Kind              MovementKind  `json:"kind"`
+	AmountSentSat     uint64        `json:"amount_sent_sat"`
+	AmountReceivedSat uint64        `json:"amount_received_sat"`
+	FeesSat           uint64        `json:"fees_sat"`
+	CreatedAt         string        `json:"created_at"`
+	Rate              *MovementRate `json:"rate,omitempty"`
+	Imported          bool          `json:"imported,omitempty"`
+}
+
+// MarshalJSON encodes the movement with snake_case keys and the kind as
+// MovementKind.MarshalJSON does.
+func (m Movement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(movementJSON(m))
+}
+
+// UnmarshalJSON decodes the form written by MarshalJSON. The kind may also
+// be given as its number, as older payloads did.
+func (m *Movement) UnmarshalJSON(data []byte) error {
+	var decoded movementJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*m = Movement(decoded)
+	return nil
+}
+
+// MarshalJSON encodes the kind in its String form. A kind without a name,
+// such as one added by a newer native library, is encoded as its number so
+// that it still round-trips through UnmarshalJSON.
+func (k MovementKind) MarshalJSON() ([]byte, error) {
+	if _, ok := movementKindNames[k]; !ok {
+		return json.Marshal(uint(k))
+	}
+	return json.Marshal(k.String())
+}
+
+// UnmarshalJSON accepts the kind as a string or as a number.
+func (k *MovementKind) UnmarshalJSON(data []byte) error {
+	var number uint
+	if err := json.Unmarshal(data, &number); err == nil {
+		*k = MovementKind(number)
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("%w: %s", ErrErrorUnknownMovementKind, data)
+	}
+	kind, err := ParseMovementKind(name)
+	if err != nil {
+		return err
+	}
+	*k = kind
+	return nil
+}
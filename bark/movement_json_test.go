@@ -0,0 +1,82 @@
+package bark
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMovementMarshalJSON(t *testing.T) {
+	movement := Movement{
+		Id:                7,
+		Kind:              MovementKindArkoorSend,
+		AmountSentSat:     1000,
+		AmountReceivedSat: 0,
+		FeesSat:           12,
+		CreatedAt:         "2024-05-01 10:00:00",
+	}
+	data, err := json.Marshal(movement)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":7,"kind":"arkoor_send","amount_sent_sat":1000,"amount_received_sat":0,"fees_sat":12,"created_at":"2024-05-01 10:00:00"}`
+	if string(data) != want {
+		t.Errorf("json.Marshal = %s, want %s", data, want)
+	}
+
+	var decoded Movement
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, movement) {
+		t.Errorf("round trip = %+v, want %+v", decoded, movement)
+	}
+}
+
+func TestMovementMarshalJSONUnknownKind(t *testing.T) {
+	movement := Movement{Id: 1, Kind: 42, CreatedAt: "2024-05-01 10:00:00"}
+	data, err := json.Marshal(movement)
+	if err != nil {
+		t.Fatalf("json.Marshal with an unknown kind: %v", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if fields["kind"] != float64(42) {
+		t.Errorf("kind = %v, want 42", fields["kind"])
+	}
+
+	var decoded Movement
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Kind != 42 {
+		t.Errorf("round trip kind = %d, want 42", decoded.Kind)
+	}
+}
+
+func TestMovementUnmarshalJSONKind(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    MovementKind
+		wantErr error
+	}{
+		{`{"kind":"lightning_receive"}`, MovementKindLightningReceive, nil},
+		{`{"kind":9}`, MovementKindLightningReceive, nil},
+		{`{"kind":"nope"}`, 0, ErrErrorUnknownMovementKind},
+		{`{"kind":true}`, 0, ErrErrorUnknownMovementKind},
+	}
+	for _, tt := range tests {
+		var movement Movement
+		err := json.Unmarshal([]byte(tt.data), &movement)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("json.Unmarshal(%s) error = %v, want %v", tt.data, err, tt.wantErr)
+			continue
+		}
+		if err == nil && movement.Kind != tt.want {
+			t.Errorf("json.Unmarshal(%s) kind = %d, want %d", tt.data, movement.Kind, tt.want)
+		}
+	}
+}
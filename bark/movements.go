@@ -0,0 +1,243 @@
+package bark
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// movementKey identifies a movement. Imported movements have ids of their
+// own, so the id alone is not enough.
+type movementKey struct {
+	id       uint32
+	imported bool
+}
+
+func (m Movement) key() movementKey {
+	return movementKey{id: m.Id, imported: m.Imported}
+}
+
+// newMovements returns the movements in after that are not in before.
+func newMovements(before []Movement, after []Movement) []Movement {
+	seen := make(map[movementKey]struct{}, len(before))
+	for _, movement := range before {
+		seen[movement.key()] = struct{}{}
+	}
+	var fresh []Movement
+	for _, movement := range after {
+		if _, ok := seen[movement.key()]; !ok {
+			fresh = append(fresh, movement)
+		}
+	}
+	return fresh
+}
+
+// movementRates are the rates from SetMovementRate and
+// SetImportedMovementRate.
+type movementRates struct {
+	native   map[uint32]MovementRate
+	imported map[uint32]MovementRate
+}
+
+func (r movementRates) apply(movement *Movement) {
+	rates := r.native
+	if movement.Imported {
+		rates = r.imported
+	}
+	if rate, ok := rates[movement.Id]; ok {
+		movement.Rate = &rate
+	}
+}
+
+// movementMetadata returns the imported movements, marked as such, and the
+// rates kept in meta.
+func movementMetadata(meta *walletMeta) ([]Movement, movementRates) {
+	imported := make([]Movement, len(meta.ImportedMovements))
+	for i, movement := range meta.ImportedMovements {
+		movement.Imported = true
+		imported[i] = movement
+	}
+	return imported, movementRates{native: meta.MovementRates, imported: meta.ImportedMovementRates}
+}
+
+// SortKey selects the field MovementsSorted orders by.
+type SortKey uint
+
+const (
+	// SortKeyId orders movements by id, which increases as they are
+	// recorded.
+	SortKeyId SortKey = iota
+	// SortKeyCreatedAt orders movements by their creation time.
+	SortKeyCreatedAt
+	// SortKeyFees orders movements by the fees paid.
+	SortKeyFees
+)
+
+// Movements returns the wallet's movements, newest first (by descending
+// id).
+func (_self *Wallet) Movements() ([]Movement, error) {
+	return _self.MovementsSorted(SortKeyId, true)
+}
+
+// MovementsSorted returns the wallet's movements ordered by the given key,
+// ascending unless desc is set. Movements that compare equal are ordered by
+// id in the same direction, so the order is always deterministic.
+func (_self *Wallet) MovementsSorted(by SortKey, desc bool) (movements []Movement, err error) {
+	defer logCall("Movements")(&err)
+	movements, err = _self.movementsNative()
+	if err != nil {
+		return nil, err
+	}
+	movements = _self.withMetadata(movements)
+	sortMovements(movements, by, desc)
+	return movements, nil
+}
+
+func sortMovements(movements []Movement, by SortKey, desc bool) {
+	slices.SortStableFunc(movements, func(a, b Movement) int {
+		var c int
+		switch by {
+		case SortKeyCreatedAt:
+			c = strings.Compare(a.CreatedAt, b.CreatedAt)
+		case SortKeyFees:
+			c = cmp.Compare(a.FeesSat, b.FeesSat)
+		}
+		if c == 0 {
+			c = cmp.Compare(a.Id, b.Id)
+		}
+		if c == 0 && a.Imported != b.Imported {
+			// Native before imported in ascending order.
+			c = 1
+			if !a.Imported {
+				c = -1
+			}
+		}
+		if desc {
+			c = -c
+		}
+		return c
+	})
+}
+
+// Vtxos returns the wallet's vtxos, soonest to expire first. Vtxos with the
+// same expiry are ordered by outpoint.
+func (_self *Wallet) Vtxos() (vtxos []Vtxo, err error) {
+	defer logCall("Vtxos")(&err)
+	vtxos, err = _self.vtxosNative()
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(vtxos, func(a, b Vtxo) int {
+		if c := cmp.Compare(a.ExpiryHeight, b.ExpiryHeight); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Point.Txid, b.Point.Txid); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Point.Vout, b.Point.Vout)
+	})
+	return vtxos, nil
+}
+
+// withMetadata adds the movements from ImportMovements and fills in the
+// rates from SetMovementRate and SetImportedMovementRate.
+func (_self *Wallet) withMetadata(movements []Movement) []Movement {
+	store, err := _self.metaStore()
+	if err != nil {
+		return movements
+	}
+	var (
+		imported []Movement
+		rates    movementRates
+	)
+	store.view(func(meta *walletMeta) {
+		imported, rates = movementMetadata(meta)
+	})
+	movements = append(movements, imported...)
+	for i := range movements {
+		rates.apply(&movements[i])
+	}
+	return movements
+}
+
+// MovementsPaged returns at most limit movements, skipping the first offset,
+// in the order of Movements. A limit of zero returns all remaining
+// movements, and an offset past the end returns an empty slice.
+//
+// The native library only returns the full list, so the window is cut in
+// Go; the returned slice is a copy that holds no more than the window.
+func (_self *Wallet) MovementsPaged(offset, limit uint32) ([]Movement, error) {
+	movements, err := _self.Movements()
+	if err != nil {
+		return nil, err
+	}
+	return pageMovements(movements, offset, limit), nil
+}
+
+func pageMovements(movements []Movement, offset, limit uint32) []Movement {
+	if uint64(offset) >= uint64(len(movements)) {
+		return []Movement{}
+	}
+	movements = movements[offset:]
+	if limit != 0 && uint64(limit) < uint64(len(movements)) {
+		movements = movements[:limit]
+	}
+	return slices.Clone(movements)
+}
+
+// MovementsCount returns the number of movements Movements would return.
+func (_self *Wallet) MovementsCount() (uint32, error) {
+	movements, err := _self.Movements()
+	if err != nil {
+		return 0, err
+	}
+	return uint32(len(movements)), nil
+}
+
+// movementTimeLayouts are the CreatedAt layouts MovementsBetween accepts:
+// RFC 3339 with optional fractional seconds, and the space-separated form
+// SQLite writes, which has no zone and is taken as UTC.
+var movementTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// CreatedTime parses the movement's CreatedAt in one of the layouts in
+// movementTimeLayouts. It fails with ErrErrorInvalidTimestamp if none
+// matches.
+func (m Movement) CreatedTime() (time.Time, error) {
+	for _, layout := range movementTimeLayouts {
+		if t, err := time.Parse(layout, m.CreatedAt); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: movement %d: %q", ErrErrorInvalidTimestamp, m.Id, m.CreatedAt)
+}
+
+// MovementsBetween returns the movements created between from and to,
+// both inclusive, in the order of Movements. It fails if any movement's
+// timestamp cannot be parsed rather than leaving it out.
+func (_self *Wallet) MovementsBetween(from, to time.Time) ([]Movement, error) {
+	movements, err := _self.Movements()
+	if err != nil {
+		return nil, err
+	}
+	return movementsBetween(movements, from, to)
+}
+
+func movementsBetween(movements []Movement, from, to time.Time) ([]Movement, error) {
+	between := []Movement{}
+	for _, movement := range movements {
+		created, err := movement.CreatedTime()
+		if err != nil {
+			return nil, err
+		}
+		if !created.Before(from) && !created.After(to) {
+			between = append(between, movement)
+		}
+	}
+	return between, nil
+}
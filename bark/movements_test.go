@@ -0,0 +1,172 @@
+package bark
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testMovements returns n movements with ids n down to 1, in the order of
+// Wallet.Movements.
+func testMovements(n int) []Movement {
+	movements := make([]Movement, n)
+	for i := range movements {
+		movements[i] = Movement{Id: uint32(n - i)}
+	}
+	return movements
+}
+
+func movementIds(movements []Movement) []uint32 {
+	ids := make([]uint32, len(movements))
+	for i, movement := range movements {
+		ids[i] = movement.Id
+	}
+	return ids
+}
+
+func TestPageMovements(t *testing.T) {
+	tests := []struct {
+		name          string
+		offset, limit uint32
+		want          []uint32
+	}{
+		{"first page", 0, 2, []uint32{5, 4}},
+		{"middle page", 2, 2, []uint32{3, 2}},
+		{"last partial page", 4, 2, []uint32{1}},
+		{"limit zero returns all remaining", 1, 0, []uint32{4, 3, 2, 1}},
+		{"limit past the end", 3, 100, []uint32{2, 1}},
+		{"offset at the end", 5, 2, []uint32{}},
+		{"offset beyond the end", 100, 0, []uint32{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := pageMovements(testMovements(5), tt.offset, tt.limit)
+			if page == nil {
+				t.Fatal("page is nil")
+			}
+			if got := movementIds(page); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ids = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageMovementsCapsAllocation(t *testing.T) {
+	page := pageMovements(testMovements(1000), 10, 3)
+	if cap(page) > 3 {
+		t.Errorf("cap(page) = %d, want at most 3", cap(page))
+	}
+}
+
+func TestMovementCreatedTime(t *testing.T) {
+	want := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	for _, createdAt := range []string{
+		"2024-05-01T10:00:00Z",
+		"2024-05-01T12:00:00+02:00",
+		"2024-05-01 10:00:00",
+		"2024-05-01 10:00:00.000",
+		"2024-05-01 10:00:00Z",
+	} {
+		got, err := Movement{CreatedAt: createdAt}.CreatedTime()
+		if err != nil {
+			t.Errorf("CreatedTime(%q): %v", createdAt, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("CreatedTime(%q) = %v, want %v", createdAt, got, want)
+		}
+	}
+}
+
+func TestMovementsBetween(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 31, 23, 59, 59, 0, time.UTC)
+	movements := []Movement{
+		{Id: 5, CreatedAt: "2024-06-01 00:00:00"},
+		{Id: 4, CreatedAt: "2024-05-31 23:59:59"},
+		{Id: 3, CreatedAt: "2024-05-15T12:00:00Z"},
+		{Id: 2, CreatedAt: "2024-05-01 00:00:00"},
+		{Id: 1, CreatedAt: "2024-04-30 23:59:59"},
+	}
+	between, err := movementsBetween(movements, from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := movementIds(between), []uint32{4, 3, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ids = %v, want %v", got, want)
+	}
+}
+
+func TestMovementsBetweenNoneInRange(t *testing.T) {
+	from := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	between, err := movementsBetween([]Movement{{Id: 1, CreatedAt: "2024-05-01 00:00:00"}}, from, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if between == nil || len(between) != 0 {
+		t.Errorf("between = %#v, want an empty slice", between)
+	}
+}
+
+func TestMovementsBetweenBadTimestamp(t *testing.T) {
+	movements := []Movement{
+		{Id: 2, CreatedAt: "2024-05-01 00:00:00"},
+		{Id: 1, CreatedAt: "yesterday"},
+	}
+	_, err := movementsBetween(movements, time.Time{}, time.Now())
+	if !errors.Is(err, ErrErrorInvalidTimestamp) {
+		t.Errorf("error = %v, want ErrErrorInvalidTimestamp", err)
+	}
+}
+
+func TestWithMetadataImported(t *testing.T) {
+	wallet, _ := newTestWallet(t)
+	withTestMeta(t, wallet)
+	err := wallet.state.meta.update(func(meta *walletMeta) error {
+		added := addImportedMovements(meta, []Movement{{Id: 2}, {Id: 5}})
+		if len(added) != 2 {
+			t.Errorf("added %d movements, want 2", len(added))
+		}
+		// Only imported ids count as duplicates.
+		if added := addImportedMovements(meta, []Movement{{Id: 2}, {Id: 1}}); len(added) != 1 || added[0].Id != 1 {
+			t.Errorf("added %v, want only movement 1", added)
+		}
+		meta.MovementRates = map[uint32]MovementRate{2: {Currency: "USD", Rate: 1}}
+		meta.ImportedMovementRates = map[uint32]MovementRate{2: {Currency: "EUR", Rate: 2}}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	movements := wallet.withMetadata(testMovements(3))
+	sortMovements(movements, SortKeyId, true)
+	type entry struct {
+		id       uint32
+		imported bool
+		currency string
+	}
+	var got []entry
+	for _, movement := range movements {
+		e := entry{id: movement.Id, imported: movement.Imported}
+		if movement.Rate != nil {
+			e.currency = movement.Rate.Currency
+		}
+		got = append(got, e)
+	}
+	want := []entry{
+		{5, true, ""},
+		{3, false, ""},
+		{2, true, "EUR"},
+		{2, false, "USD"},
+		{1, true, ""},
+		{1, false, ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if fresh := newMovements(testMovements(3), movements); len(fresh) != 3 {
+		t.Errorf("newMovements found %d new movements, want the 3 imported", len(fresh))
+	}
+}
@@ -0,0 +1,44 @@
+package bark
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxWalletNameLength is the longest name, in characters, SetName accepts.
+const MaxWalletNameLength = 64
+
+// SetName stores a display name for the wallet, such as "Savings". It is
+// only metadata and has no effect on the wallet itself. An empty name clears
+// it.
+func (_self *Wallet) SetName(name string) error {
+	name = strings.TrimSpace(name)
+	if !utf8.ValidString(name) {
+		return fmt.Errorf("%w: name is not valid UTF-8", ErrErrorInvalidLabel)
+	}
+	if n := utf8.RuneCountInString(name); n > MaxWalletNameLength {
+		return fmt.Errorf("%w: name is %d characters, the limit is %d", ErrErrorInvalidLabel, n, MaxWalletNameLength)
+	}
+	store, err := _self.metaStore()
+	if err != nil {
+		return err
+	}
+	return store.update(func(meta *walletMeta) error {
+		meta.Name = name
+		return nil
+	})
+}
+
+// Name returns the display name set with SetName, or "" if there is none.
+func (_self *Wallet) Name() (string, error) {
+	store, err := _self.metaStore()
+	if err != nil {
+		return "", err
+	}
+	var name string
+	store.view(func(meta *walletMeta) {
+		name = meta.Name
+	})
+	return name, nil
+}
@@ -0,0 +1,32 @@
+package bark
+
+import "fmt"
+
+// The networks the native library accepts, named as rust-bitcoin prints
+// them.
+const (
+	NetworkBitcoin Network = "bitcoin"
+	NetworkTestnet Network = "testnet"
+	NetworkSignet  Network = "signet"
+	NetworkRegtest Network = "regtest"
+)
+
+var supportedNetworks = []Network{NetworkBitcoin, NetworkTestnet, NetworkSignet, NetworkRegtest}
+
+// SupportedNetworks returns the networks a wallet can be created for. The
+// native library has no call to list them, so this is the set compiled into
+// the bindings.
+func SupportedNetworks() []Network {
+	return append([]Network(nil), supportedNetworks...)
+}
+
+// ParseNetwork returns the network named name. It fails with
+// ErrErrorInvalidNetwork for names the native library does not accept.
+func ParseNetwork(name string) (Network, error) {
+	for _, network := range supportedNetworks {
+		if string(network) == name {
+			return network, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrErrorInvalidNetwork, name)
+}
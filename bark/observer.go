@@ -0,0 +1,128 @@
+package bark
+
+import "sync"
+
+// observerQueueSize is how many events may be waiting for an observer
+// before further events for it are dropped.
+const observerQueueSize = 64
+
+// Observer receives wallet events registered with AddObserver. Methods are
+// called from a goroutine owned by the observer, one at a time and in order,
+// so a slow observer never blocks the wallet or other observers. If an
+// observer falls more than observerQueueSize events behind, newer events are
+// dropped for it.
+type Observer interface {
+	// OnBalanceChanged is called after a sync that changed the balance.
+	OnBalanceChanged(balance WalletBalance)
+	// OnMovement is called for each movement that appeared during a sync.
+	OnMovement(movement Movement)
+	// OnSyncComplete is called after each successful Sync or Maintenance.
+	OnSyncComplete()
+	// OnError is called when Sync or Maintenance fails.
+	OnError(err error)
+}
+
+// observers holds the registered observers and what they have been told so
+// far.
+type observers struct {
+	mu      sync.Mutex
+	nextID  uint64
+	queues  map[uint64]chan func(Observer)
+	balance *WalletBalance
+	// movements is the movement list as of the last notification.
+	movements []Movement
+}
+
+// AddObserver registers observer for wallet events until remove is called or
+// the wallet is destroyed.
+func (_self *Wallet) AddObserver(observer Observer) (remove func()) {
+	s := &_self.state.observers
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.queues == nil {
+		s.queues = make(map[uint64]chan func(Observer))
+	}
+	if len(s.queues) == 0 {
+		// Take a baseline so the first sync only reports what changed.
+		if balance, err := _self.WalletBalance(); err == nil {
+			s.balance = &balance
+		}
+		if movements, err := _self.Movements(); err == nil {
+			s.movements = movements
+		}
+	}
+
+	id := s.nextID
+	s.nextID++
+	queue := make(chan func(Observer), observerQueueSize)
+	s.queues[id] = queue
+	go func() {
+		for event := range queue {
+			event(observer)
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if queue, ok := s.queues[id]; ok {
+				delete(s.queues, id)
+				close(queue)
+			}
+		})
+	}
+}
+
+// notifyObservers reports the outcome of a sync. It does nothing if there
+// are no observers.
+func (_self *Wallet) notifyObservers(syncErr error) {
+	s := &_self.state.observers
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.queues) == 0 {
+		return
+	}
+	if syncErr != nil {
+		s.dispatch(func(o Observer) { o.OnError(syncErr) })
+		return
+	}
+
+	if balance, err := _self.WalletBalance(); err != nil {
+		s.dispatch(func(o Observer) { o.OnError(err) })
+	} else if s.balance == nil || *s.balance != balance {
+		s.balance = &balance
+		s.dispatch(func(o Observer) { o.OnBalanceChanged(balance) })
+	}
+	if movements, err := _self.Movements(); err != nil {
+		s.dispatch(func(o Observer) { o.OnError(err) })
+	} else {
+		for _, movement := range newMovements(s.movements, movements) {
+			s.dispatch(func(o Observer) { o.OnMovement(movement) })
+		}
+		s.movements = movements
+	}
+	s.dispatch(func(o Observer) { o.OnSyncComplete() })
+}
+
+// dispatch queues event for every observer, dropping it for those whose
+// queue is full. s.mu must be held.
+func (s *observers) dispatch(event func(Observer)) {
+	for _, queue := range s.queues {
+		select {
+		case queue <- event:
+		default:
+		}
+	}
+}
+
+// closeAll removes every observer.
+func (s *observers) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, queue := range s.queues {
+		delete(s.queues, id)
+		close(queue)
+	}
+}
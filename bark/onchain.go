@@ -0,0 +1,38 @@
+package bark
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+)
+
+// OnchainTransactionFeeRate returns the fee rate, in sat/vB, that the
+// onchain transaction txid paid. The fee and size are looked up through the
+// wallet's Esplora endpoint.
+func (_self *Wallet) OnchainTransactionFeeRate(txid string) (float64, error) {
+	if err := validateTxid(txid); err != nil {
+		return 0, err
+	}
+	esplora, err := _self.esplora()
+	if err != nil {
+		return 0, err
+	}
+	tx, err := esplora.transaction(context.Background(), txid)
+	if err != nil {
+		return 0, err
+	}
+	if tx.vsize() == 0 {
+		return 0, fmt.Errorf("%w: transaction %s has no size", ErrErrorEsploraFailed, txid)
+	}
+	return float64(tx.Fee) / float64(tx.vsize()), nil
+}
+
+func validateTxid(txid string) error {
+	if len(txid) != 64 {
+		return fmt.Errorf("%w: txid must be 64 hex characters", ErrErrorInvalidTransaction)
+	}
+	if _, err := hex.DecodeString(txid); err != nil {
+		return fmt.Errorf("%w: txid: %v", ErrErrorInvalidTransaction, err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package bark
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// onchainFeeTarget is the number of blocks within which EstimateOnchainFee
+// prices a send to confirm.
+const onchainFeeTarget = 6
+
+// Approximate transaction sizes, in vbytes, used by EstimateOnchainFee. The
+// wallet's own inputs and change are taken to be taproot key spends.
+const (
+	txOverheadVbytes    = 11
+	taprootInputVbytes  = 58
+	taprootOutputVbytes = 43
+)
+
+// OnchainFeeEstimate is the estimated cost of a SendOnchain.
+type OnchainFeeEstimate struct {
+	FeeSat          uint64
+	FeeRateSatPerVb float64
+	EstimatedVbytes uint64
+}
+
+// EstimateOnchainFee estimates the fee SendOnchain would pay to send
+// amountSats to address, at the rate Esplora gives for confirmation within
+// onchainFeeTarget blocks. Nothing is signed or broadcast.
+//
+// The address is checked against the wallet's network with
+// ValidateBitcoinAddress. Inputs are picked largest first from the UtxoLocal
+// outputs in Utxos, which may differ from the native wallet's coin
+// selection, and a change output is always counted, so the estimate errs on
+// the high side.
+func (_self *Wallet) EstimateOnchainFee(address string, amountSats uint64) (OnchainFeeEstimate, error) {
+	address = strings.TrimSpace(address)
+	network, err := _self.network()
+	if err != nil {
+		return OnchainFeeEstimate{}, err
+	}
+	if err := ValidateBitcoinAddress(address, network); err != nil {
+		return OnchainFeeEstimate{}, err
+	}
+	esplora, err := _self.esplora()
+	if err != nil {
+		return OnchainFeeEstimate{}, err
+	}
+	rate, err := esplora.feeRate(context.Background(), onchainFeeTarget)
+	if err != nil {
+		return OnchainFeeEstimate{}, err
+	}
+	return estimateOnchainFee(address, amountSats, rate, _self.Utxos())
+}
+
+// estimateOnchainFee picks inputs for EstimateOnchainFee from the onchain
+// wallet's own outputs among utxos, largest first, and prices the result at
+// rate sat/vB. Exit outputs are not the wallet's to spend and are skipped.
+func estimateOnchainFee(address string, amountSats uint64, rate uint64, utxos []Utxo) (OnchainFeeEstimate, error) {
+	var amounts []uint64
+	for _, utxo := range utxos {
+		if utxo.Kind() == UtxoKindLocal {
+			amounts = append(amounts, utxo.ValueSat())
+		}
+	}
+	slices.SortFunc(amounts, func(a, b uint64) int { return cmp.Compare(b, a) })
+
+	vbytes := uint64(txOverheadVbytes + outputVbytes(address) + taprootOutputVbytes)
+	var selected uint64
+	for _, amount := range amounts {
+		vbytes += taprootInputVbytes
+		selected += amount
+		if selected >= amountSats+vbytes*rate {
+			return OnchainFeeEstimate{
+				FeeSat:          vbytes * rate,
+				FeeRateSatPerVb: float64(rate),
+				EstimatedVbytes: vbytes,
+			}, nil
+		}
+	}
+	return OnchainFeeEstimate{}, fmt.Errorf("%w: sending %d sat plus fees, %d sat onchain",
+		ErrErrorInsufficientFunds, amountSats, selected)
+}
+
+// outputVbytes is the size of an output paying to address, which must have
+// passed ValidateBitcoinAddress.
+func outputVbytes(address string) uint64 {
+	_, data, _, err := bech32Decode(address)
+	if err != nil {
+		// Base58: P2PKH addresses start with 1, m or n, P2SH with 3 or 2.
+		if strings.ContainsRune("1mn", rune(address[0])) {
+			return 34
+		}
+		return 32
+	}
+	if data[0] == 0 && len(data) == 33 {
+		// Version 0 with a 20-byte program: P2WPKH.
+		return 31
+	}
+	return 43
+}
+
+// network returns the wallet's network from its recorded config, or from
+// the ASP if there is none.
+func (_self *Wallet) network() (Network, error) {
+	if config, err := _self.config(); err == nil {
+		return config.Network, nil
+	}
+	info, err := _self.ArkInfo()
+	if err != nil {
+		return "", err
+	}
+	return info.Network, nil
+}
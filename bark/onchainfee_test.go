@@ -0,0 +1,66 @@
+package bark
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testP2wpkhAddress = "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"
+
+func TestEstimateOnchainFee(t *testing.T) {
+	esplora := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fee-estimates" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"1": 20.5, "3": 12.0, "6": 7.2, "144": 1.0}`))
+	}))
+	defer esplora.Close()
+
+	rate, err := newEsploraClient(esplora.URL).feeRate(context.Background(), onchainFeeTarget)
+	if err != nil {
+		t.Fatal(err)
+	}
+	utxos := []Utxo{
+		UtxoLocal{AmountSat: 30_000},
+		UtxoLocal{AmountSat: 50_000},
+	}
+	estimate, err := estimateOnchainFee(testP2wpkhAddress, 60_000, rate, utxos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Both inputs are needed, with a P2WPKH output and taproot change.
+	wantVbytes := uint64(txOverheadVbytes + 31 + taprootOutputVbytes + 2*taprootInputVbytes)
+	if estimate.EstimatedVbytes != wantVbytes {
+		t.Errorf("EstimatedVbytes = %d, want %d", estimate.EstimatedVbytes, wantVbytes)
+	}
+	if estimate.FeeRateSatPerVb != float64(rate) {
+		t.Errorf("FeeRateSatPerVb = %v, want %d", estimate.FeeRateSatPerVb, rate)
+	}
+	if want := rate * estimate.EstimatedVbytes; estimate.FeeSat != want {
+		t.Errorf("FeeSat = %d, want rate × vbytes = %d", estimate.FeeSat, want)
+	}
+}
+
+func TestEstimateOnchainFeeSkipsExits(t *testing.T) {
+	utxos := []Utxo{
+		UtxoLocal{AmountSat: 10_000},
+		UtxoExit{Vtxo: Vtxo{AmountSat: 1_000_000}, Height: 100},
+	}
+	_, err := estimateOnchainFee(testP2wpkhAddress, 50_000, 2, utxos)
+	if !errors.Is(err, ErrErrorInsufficientFunds) {
+		t.Fatalf("error = %v, want ErrErrorInsufficientFunds", err)
+	}
+}
+
+func TestEstimateOnchainFeeInsufficientFunds(t *testing.T) {
+	utxos := []Utxo{UtxoLocal{AmountSat: 10_000}}
+	// The amount alone fits, but not with the fee on top.
+	_, err := estimateOnchainFee(testP2wpkhAddress, 10_000, 1, utxos)
+	if !errors.Is(err, ErrErrorInsufficientFunds) {
+		t.Fatalf("error = %v, want ErrErrorInsufficientFunds", err)
+	}
+}
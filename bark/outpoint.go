@@ -0,0 +1,30 @@
+package bark
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns the outpoint as "<txid>:<vout>".
+func (o OutPoint) String() string {
+	return o.Txid + ":" + strconv.FormatUint(uint64(o.Vout), 10)
+}
+
+// ParseOutPoint parses an outpoint in the form returned by OutPoint.String.
+// It splits on the last colon and fails with ErrErrorInvalidOutPoint if
+// there is none, the txid is empty or the vout is not a uint32.
+func ParseOutPoint(s string) (OutPoint, error) {
+	i := strings.LastIndexByte(s, ':')
+	if i < 0 {
+		return OutPoint{}, fmt.Errorf("%w: missing colon in %q", ErrErrorInvalidOutPoint, s)
+	}
+	if i == 0 {
+		return OutPoint{}, fmt.Errorf("%w: missing txid in %q", ErrErrorInvalidOutPoint, s)
+	}
+	vout, err := strconv.ParseUint(s[i+1:], 10, 32)
+	if err != nil {
+		return OutPoint{}, fmt.Errorf("%w: invalid vout in %q", ErrErrorInvalidOutPoint, s)
+	}
+	return OutPoint{Txid: s[:i], Vout: uint32(vout)}, nil
+}
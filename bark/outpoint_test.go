@@ -0,0 +1,53 @@
+package bark
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOutPointRoundTrip(t *testing.T) {
+	txid := "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b"
+	for _, point := range []OutPoint{
+		{Txid: txid, Vout: 0},
+		{Txid: txid, Vout: 7},
+		{Txid: txid, Vout: 4294967295},
+	} {
+		s := point.String()
+		parsed, err := ParseOutPoint(s)
+		if err != nil {
+			t.Fatalf("ParseOutPoint(%q): %v", s, err)
+		}
+		if parsed != point {
+			t.Errorf("ParseOutPoint(%q) = %+v, want %+v", s, parsed, point)
+		}
+	}
+	if got := (OutPoint{Txid: "ab", Vout: 3}).String(); got != "ab:3" {
+		t.Errorf("String = %q, want ab:3", got)
+	}
+}
+
+func TestParseOutPointLastColon(t *testing.T) {
+	parsed, err := ParseOutPoint("a:b:5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Txid != "a:b" || parsed.Vout != 5 {
+		t.Errorf("ParseOutPoint = %+v, want txid a:b and vout 5", parsed)
+	}
+}
+
+func TestParseOutPointInvalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"4a5e1e4b",            // missing colon
+		":0",                  // missing txid
+		"4a5e1e4b:",           // empty vout
+		"4a5e1e4b:one",        // non-numeric vout
+		"4a5e1e4b:-1",         // negative vout
+		"4a5e1e4b:4294967296", // vout beyond uint32
+	} {
+		if _, err := ParseOutPoint(s); !errors.Is(err, ErrErrorInvalidOutPoint) {
+			t.Errorf("ParseOutPoint(%q) error = %v, want ErrErrorInvalidOutPoint", s, err)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package bark
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+var recoverPanics atomic.Bool
+
+// SetRecoverPanics controls what happens when the native library panics
+// during a call that can fail. By default the panic is re-raised as a Go
+// panic, which takes down the process unless the caller recovers it. When
+// enabled, the call instead returns an error wrapping ErrErrorBarkFailed
+// with the panic message. Calls that cannot return an error still panic.
+func SetRecoverPanics(enabled bool) {
+	recoverPanics.Store(enabled)
+}
+
+// recoverRustPanic is deferred by rustCallWithError. It turns a panic raised
+// while checking the call status into an ErrorBarkFailed in *err when
+// SetRecoverPanics is on and E is Error.
+func recoverRustPanic[E any](err **E) {
+	if !recoverPanics.Load() {
+		return
+	}
+	if _, ok := any((*E)(nil)).(*Error); !ok {
+		return
+	}
+	r := recover()
+	if r == nil {
+		return
+	}
+	failed := &Error{err: &ErrorBarkFailed{message: fmt.Sprintf("native panic: %v", r)}}
+	*err = any(failed).(*E)
+}
@@ -0,0 +1,48 @@
+package bark
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+)
+
+// nativePanic stands in for an FFI call whose native side panicked: it sets
+// the call status code to 2 and leaves the error buffer empty. It is generic
+// in the status type because tests cannot refer to cgo types.
+func nativePanic[S any](status *S) int {
+	*(*int8)(unsafe.Pointer(status)) = 2
+	return 0
+}
+
+func TestRecoverPanics(t *testing.T) {
+	SetRecoverPanics(true)
+	t.Cleanup(func() { SetRecoverPanics(false) })
+
+	var err *Error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("panicked with recovery on: %v", r)
+			}
+		}()
+		_, err = rustCallWithError[Error](FfiConverterError{}, nativePanic)
+	}()
+	if err == nil {
+		t.Fatal("no error returned for a native panic")
+	}
+	if !errors.Is(err, ErrErrorBarkFailed) {
+		t.Errorf("error = %v, want ErrErrorBarkFailed", err)
+	}
+	if err.Kind() != ErrorKindBarkFailed || err.Message() == "" {
+		t.Errorf("error kind %v with message %q, want BarkFailed with the panic", err.Kind(), err.Message())
+	}
+}
+
+func TestRecoverPanicsOffByDefault(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("native panic did not panic with recovery off")
+		}
+	}()
+	rustCallWithError[Error](FfiConverterError{}, nativePanic)
+}
@@ -0,0 +1,89 @@
+package bark
+
+import "fmt"
+
+// SetAspPubkeyPin pins the ASP's public key. If a later sync finds that
+// ArkInfo.AspPubkey differs from the pin, operations that talk to the ASP
+// fail with ErrErrorAspPubkeyMismatch until a sync sees the pinned key again.
+// Sync itself keeps working so the wallet can notice when that happens. An
+// empty pubkey removes the pin. The pin is persisted with the wallet, and a
+// reopened wallet checks it before its first call to the ASP.
+func (_self *Wallet) SetAspPubkeyPin(pubkey PublicKey) error {
+	if pubkey != "" {
+		var err error
+		if pubkey, err = parsePublicKey(pubkey); err != nil {
+			return err
+		}
+	}
+	store, err := _self.metaStore()
+	if err != nil {
+		return err
+	}
+	err = store.update(func(meta *walletMeta) error {
+		meta.AspPubkeyPin = pubkey
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if pubkey == "" {
+		_self.state.aspMismatch.Store(nil)
+		_self.state.aspChecked.Store(true)
+		return nil
+	}
+	info, err := _self.ArkInfo()
+	if err != nil {
+		// Checked again at the next sync.
+		return nil
+	}
+	return _self.checkAspPubkey(info.AspPubkey)
+}
+
+func (_self *Wallet) aspPubkeyPin() string {
+	store, err := _self.metaStore()
+	if err != nil {
+		return ""
+	}
+	var pin string
+	store.view(func(meta *walletMeta) {
+		pin = meta.AspPubkeyPin
+	})
+	return pin
+}
+
+// ensureAspChecked compares the pin with the ASP's key if that has not been
+// done since the wallet was opened, so that a pinned wallet does not talk to
+// the ASP before its key is known to match. If the key cannot be fetched the
+// check fails and is tried again next time.
+func (_self *Wallet) ensureAspChecked(arkInfo func() (ArkInfo, error)) error {
+	if _self.state.aspChecked.Load() {
+		return nil
+	}
+	if _self.aspPubkeyPin() == "" {
+		return nil
+	}
+	info, err := arkInfo()
+	if err != nil {
+		return err
+	}
+	return _self.checkAspPubkey(info.AspPubkey)
+}
+
+// checkAspPubkey compares the ASP's current key with the pin and records
+// the outcome for beforeAsp.
+func (_self *Wallet) checkAspPubkey(current PublicKey) error {
+	_self.state.aspChecked.Store(true)
+	pin := _self.aspPubkeyPin()
+	if pin == "" {
+		_self.state.aspMismatch.Store(nil)
+		return nil
+	}
+	normalized, err := parsePublicKey(current)
+	if err == nil && normalized == pin {
+		_self.state.aspMismatch.Store(nil)
+		return nil
+	}
+	mismatch := fmt.Errorf("%w: pinned %s, ASP presented %s", ErrErrorAspPubkeyMismatch, pin, current)
+	_self.state.aspMismatch.Store(&mismatch)
+	return mismatch
+}
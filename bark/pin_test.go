@@ -0,0 +1,81 @@
+package bark
+
+import (
+	"errors"
+	"testing"
+)
+
+const (
+	testAspKey   = "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+	testOtherKey = "02c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5"
+)
+
+func TestEnsureAspChecked(t *testing.T) {
+	arkInfo := func(key PublicKey, err error) func() (ArkInfo, error) {
+		return func() (ArkInfo, error) {
+			return ArkInfo{AspPubkey: key}, err
+		}
+	}
+	errOffline := errors.New("offline")
+
+	tests := []struct {
+		name    string
+		pin     PublicKey
+		arkInfo func() (ArkInfo, error)
+		want    error
+	}{
+		{"no pin", "", arkInfo("", errOffline), nil},
+		{"match", testAspKey, arkInfo(testAspKey, nil), nil},
+		{"mismatch", testAspKey, arkInfo(testOtherKey, nil), ErrErrorAspPubkeyMismatch},
+		{"unreachable", testAspKey, arkInfo("", errOffline), errOffline},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A wallet just opened with a persisted pin, before any sync.
+			wallet, _ := newTestWallet(t)
+			withTestMeta(t, wallet)
+			err := wallet.state.meta.update(func(meta *walletMeta) error {
+				meta.AspPubkeyPin = tt.pin
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = wallet.ensureAspChecked(tt.arkInfo)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+			mismatch := wallet.state.aspMismatch.Load() != nil
+			if wantMismatch := errors.Is(tt.want, ErrErrorAspPubkeyMismatch); mismatch != wantMismatch {
+				t.Errorf("mismatch recorded %v, want %v", mismatch, wantMismatch)
+			}
+		})
+	}
+}
+
+func TestEnsureAspCheckedOnce(t *testing.T) {
+	wallet, _ := newTestWallet(t)
+	withTestMeta(t, wallet)
+	err := wallet.state.meta.update(func(meta *walletMeta) error {
+		meta.AspPubkeyPin = testAspKey
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := 0
+	arkInfo := func() (ArkInfo, error) {
+		calls++
+		return ArkInfo{AspPubkey: testAspKey}, nil
+	}
+	for range 3 {
+		if err := wallet.ensureAspChecked(arkInfo); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("ArkInfo called %d times, want 1", calls)
+	}
+}
@@ -0,0 +1,74 @@
+package bark
+
+import "fmt"
+
+// SendPreview describes what Send would do, without doing it. The native
+// library does not say whether a send will go out of round (arkoor) or wait
+// for a round, nor what fee it will pay, so neither is part of the preview.
+type SendPreview struct {
+	Destination BarkAddress
+	AmountSat   uint64
+	// Vtxos are the vtxos that would be spent and ChangeSat what would come
+	// back from them. They are chosen soonest-expiring first, which
+	// approximates the native coin selection but may not match it.
+	Vtxos     []Vtxo
+	ChangeSat uint64
+}
+
+// SendPreview checks that Send(destination, amountSats) can go through and
+// describes how it would be made, without sending anything. Like Send it
+// asks the ASP for its ark info to check the maximum vtxo size, but no round
+// is joined and nothing is signed. It fails with the errors Send would:
+// ErrErrorInvalidBarkAddress, ErrErrorInvalidAmount,
+// ErrErrorDestinationNotAllowed, ErrErrorAmountExceedsMaxVtxo,
+// ErrErrorSendLimitExceeded or ErrErrorInsufficientFunds.
+func (_self *Wallet) SendPreview(destination BarkAddress, amountSats uint64) (SendPreview, error) {
+	if err := _self.checkSend(destination, amountSats); err != nil {
+		return SendPreview{}, err
+	}
+	if err := _self.checkSendAllowance(amountSats); err != nil {
+		return SendPreview{}, err
+	}
+	balance, err := _self.WalletBalance()
+	if err != nil {
+		return SendPreview{}, err
+	}
+	if amountSats > balance.SpendableSat {
+		return SendPreview{}, fmt.Errorf("%w: sending %d sat, %d sat spendable",
+			ErrErrorInsufficientFunds, amountSats, balance.SpendableSat)
+	}
+
+	vtxos, err := _self.Vtxos()
+	if err != nil {
+		return SendPreview{}, err
+	}
+	selected, change, err := selectVtxos(vtxos, amountSats)
+	if err != nil {
+		return SendPreview{}, err
+	}
+	return SendPreview{
+		Destination: destination,
+		AmountSat:   amountSats,
+		Vtxos:       selected,
+		ChangeSat:   change,
+	}, nil
+}
+
+// selectVtxos takes vtxos in order until they cover amountSats and returns
+// them with the change left over.
+func selectVtxos(vtxos []Vtxo, amountSats uint64) (selected []Vtxo, changeSat uint64, err error) {
+	selected = []Vtxo{}
+	var total uint64
+	for _, vtxo := range vtxos {
+		if total >= amountSats {
+			break
+		}
+		selected = append(selected, vtxo)
+		total += vtxo.AmountSat
+	}
+	if total < amountSats {
+		return nil, 0, fmt.Errorf("%w: sending %d sat, vtxos hold %d sat",
+			ErrErrorInsufficientFunds, amountSats, total)
+	}
+	return selected, total - amountSats, nil
+}
@@ -0,0 +1,80 @@
+package bark
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestSendAndSendPreviewValidateAlike checks that Send and SendPreview
+// reject the same payments with the same errors. Every case fails before
+// the native library would be reached.
+func TestSendAndSendPreviewValidateAlike(t *testing.T) {
+	wallet, _ := newTestWallet(t)
+	withTestMeta(t, wallet)
+	if err := wallet.SetSendAllowlist([]string{testArkAddress(1)}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		destination BarkAddress
+		amountSats  uint64
+		wantErr     error
+	}{
+		{"onchain address", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", 10_000, ErrErrorInvalidBarkAddress},
+		{"garbage", "tark1notbech32", 10_000, ErrErrorInvalidBarkAddress},
+		{"empty", "", 10_000, ErrErrorInvalidBarkAddress},
+		{"dust", testArkAddress(1), DustLimitSat - 1, ErrErrorInvalidAmount},
+		{"not allowed", testArkAddress(2), 10_000, ErrErrorDestinationNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := wallet.Send(tt.destination, tt.amountSats); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Send error = %v, want %v", err, tt.wantErr)
+			}
+			if _, err := wallet.SendPreview(tt.destination, tt.amountSats); !errors.Is(err, tt.wantErr) {
+				t.Errorf("SendPreview error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelectVtxos(t *testing.T) {
+	vtxos := []Vtxo{
+		{AmountSat: 5_000, ExpiryHeight: 100},
+		{AmountSat: 20_000, ExpiryHeight: 200},
+		{AmountSat: 50_000, ExpiryHeight: 300},
+	}
+	selected, change, err := selectVtxos(vtxos, 12_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(selected, vtxos[:2]) {
+		t.Errorf("selected = %+v, want the two soonest-expiring vtxos", selected)
+	}
+	if change != 13_000 {
+		t.Errorf("change = %d, want 13000", change)
+	}
+}
+
+func TestSelectVtxosExactAmount(t *testing.T) {
+	vtxos := []Vtxo{{AmountSat: 5_000}, {AmountSat: 20_000}}
+	selected, change, err := selectVtxos(vtxos, 5_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selected) != 1 || change != 0 {
+		t.Errorf("selected %d vtxos with %d sat change, want 1 vtxo and no change", len(selected), change)
+	}
+}
+
+func TestSelectVtxosInsufficientFunds(t *testing.T) {
+	vtxos := []Vtxo{{AmountSat: 5_000}, {AmountSat: 20_000}}
+	if _, _, err := selectVtxos(vtxos, 25_001); !errors.Is(err, ErrErrorInsufficientFunds) {
+		t.Fatalf("error = %v, want ErrErrorInsufficientFunds", err)
+	}
+	if _, _, err := selectVtxos(nil, DustLimitSat); !errors.Is(err, ErrErrorInsufficientFunds) {
+		t.Fatalf("error with no vtxos = %v, want ErrErrorInsufficientFunds", err)
+	}
+}
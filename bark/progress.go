@@ -0,0 +1,58 @@
+package bark
+
+import "context"
+
+// Phases reported by SyncWithProgress.
+const (
+	SyncPhaseStarted = "started"
+	SyncPhaseDone    = "done"
+)
+
+// SyncProgress describes how far SyncWithProgress has got. Heights are zero
+// when unknown.
+type SyncProgress struct {
+	Phase string
+	// CurrentHeight is the height the wallet is synced to.
+	CurrentHeight uint32
+	// TipHeight is the chain tip being synced towards.
+	TipHeight uint32
+}
+
+// SyncWithProgress runs Sync, calling cb before it starts and after it
+// finishes successfully. cb is called on the calling goroutine and must not
+// call back into the wallet.
+//
+// The native sync does not report progress while it scans, so only the
+// SyncPhaseStarted and SyncPhaseDone events are sent. The tip for the first
+// is fetched from Esplora on a best-effort basis.
+func (_self *Wallet) SyncWithProgress(cb func(SyncProgress)) error {
+	started := SyncProgress{
+		Phase:         SyncPhaseStarted,
+		CurrentHeight: _self.state.syncedHeight.Load(),
+	}
+	if esplora, err := _self.esplora(); err == nil {
+		if tip, err := esplora.tipHeight(context.Background()); err == nil {
+			started.TipHeight = tip
+		}
+	}
+	return runWithProgress(started, func() (uint32, error) {
+		if err := _self.Sync(); err != nil {
+			return 0, err
+		}
+		return _self.state.syncedHeight.Load(), nil
+	}, cb)
+}
+
+// runWithProgress reports started, runs sync, which returns the height it
+// synced to, and reports SyncPhaseDone if it succeeds. The done event never
+// goes below the tip reported at the start.
+func runWithProgress(started SyncProgress, sync func() (uint32, error), cb func(SyncProgress)) error {
+	cb(started)
+	synced, err := sync()
+	if err != nil {
+		return err
+	}
+	height := max(synced, started.TipHeight)
+	cb(SyncProgress{Phase: SyncPhaseDone, CurrentHeight: height, TipHeight: height})
+	return nil
+}
@@ -0,0 +1,59 @@
+package bark
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRunWithProgressMonotonic(t *testing.T) {
+	tests := []struct {
+		name    string
+		started SyncProgress
+		synced  uint32
+	}{
+		{"catches up to the tip", SyncProgress{Phase: SyncPhaseStarted, CurrentHeight: 100, TipHeight: 150}, 150},
+		{"tip moved during sync", SyncProgress{Phase: SyncPhaseStarted, CurrentHeight: 100, TipHeight: 150}, 152},
+		{"native sync behind the tip seen at start", SyncProgress{Phase: SyncPhaseStarted, CurrentHeight: 100, TipHeight: 150}, 149},
+		{"first sync with unknown tip", SyncProgress{Phase: SyncPhaseStarted}, 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []SyncProgress
+			err := runWithProgress(tt.started, func() (uint32, error) { return tt.synced, nil }, func(p SyncProgress) {
+				events = append(events, p)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(events) != 2 || events[0].Phase != SyncPhaseStarted || events[1].Phase != SyncPhaseDone {
+				t.Fatalf("events = %+v, want started then done", events)
+			}
+			for i := 1; i < len(events); i++ {
+				if events[i].CurrentHeight < events[i-1].CurrentHeight {
+					t.Errorf("CurrentHeight went down: %+v", events)
+				}
+				if events[i].TipHeight < events[i-1].TipHeight {
+					t.Errorf("TipHeight went down: %+v", events)
+				}
+			}
+			if done := events[1]; done.CurrentHeight != done.TipHeight {
+				t.Errorf("done event %+v is not at the tip", done)
+			}
+		})
+	}
+}
+
+func TestRunWithProgressFailure(t *testing.T) {
+	var events []SyncProgress
+	err := runWithProgress(SyncProgress{Phase: SyncPhaseStarted}, func() (uint32, error) {
+		return 0, NewErrorBarkFailed()
+	}, func(p SyncProgress) {
+		events = append(events, p)
+	})
+	if !errors.Is(err, ErrErrorBarkFailed) {
+		t.Errorf("err = %v, want ErrErrorBarkFailed", err)
+	}
+	if len(events) != 1 || events[0].Phase != SyncPhaseStarted {
+		t.Errorf("events = %+v, want only the started event", events)
+	}
+}
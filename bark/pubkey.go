@@ -0,0 +1,37 @@
+package bark
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// secp256k1P is the field prime of secp256k1.
+var secp256k1P, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F", 16)
+
+// parsePublicKey checks that pubkey is a hex-encoded compressed secp256k1
+// point and returns it in lower case.
+func parsePublicKey(pubkey PublicKey) (PublicKey, error) {
+	pubkey = strings.ToLower(strings.TrimSpace(pubkey))
+	raw, err := hex.DecodeString(pubkey)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrErrorInvalidPublicKey, err)
+	}
+	if len(raw) != 33 || (raw[0] != 0x02 && raw[0] != 0x03) {
+		return "", fmt.Errorf("%w: expected a 33-byte compressed key", ErrErrorInvalidPublicKey)
+	}
+
+	x := new(big.Int).SetBytes(raw[1:])
+	if x.Cmp(secp256k1P) >= 0 {
+		return "", fmt.Errorf("%w: x coordinate out of range", ErrErrorInvalidPublicKey)
+	}
+	// The key is on the curve if x^3 + 7 is a square mod p.
+	y2 := new(big.Int).Exp(x, big.NewInt(3), secp256k1P)
+	y2.Add(y2, big.NewInt(7))
+	y2.Mod(y2, secp256k1P)
+	if new(big.Int).ModSqrt(y2, secp256k1P) == nil {
+		return "", fmt.Errorf("%w: not a point on secp256k1", ErrErrorInvalidPublicKey)
+	}
+	return pubkey, nil
+}
@@ -0,0 +1,65 @@
+package bark
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// MovementRate is the fiat exchange rate at the time of a movement, as
+// supplied by the application.
+type MovementRate struct {
+	// Currency is an ISO 4217 code such as "USD".
+	Currency string `json:"currency"`
+	// Rate is the price of one bitcoin in Currency.
+	Rate float64 `json:"rate"`
+}
+
+// SetMovementRate records the fiat rate for the native movement with the
+// given id, replacing any rate recorded before. The wallet does not fetch
+// rates; the application supplies them. Rates are persisted with the wallet
+// and returned in Movement.Rate.
+func (_self *Wallet) SetMovementRate(id uint32, currency string, rate float64) error {
+	return _self.setMovementRate(movementKey{id: id}, currency, rate)
+}
+
+// SetImportedMovementRate is SetMovementRate for the movement with the
+// given id added by ImportMovements.
+func (_self *Wallet) SetImportedMovementRate(id uint32, currency string, rate float64) error {
+	return _self.setMovementRate(movementKey{id: id, imported: true}, currency, rate)
+}
+
+func (_self *Wallet) setMovementRate(key movementKey, currency string, rate float64) error {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if len(currency) != 3 || strings.ContainsFunc(currency, func(r rune) bool { return r < 'A' || r > 'Z' }) {
+		return fmt.Errorf("%w: currency %q is not an ISO 4217 code", ErrErrorInvalidAmount, currency)
+	}
+	if rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+		return fmt.Errorf("%w: rate %v", ErrErrorInvalidAmount, rate)
+	}
+
+	movements, err := _self.Movements()
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(movements, func(m Movement) bool { return m.key() == key }) {
+		return fmt.Errorf("%w: %d", ErrErrorUnknownMovement, key.id)
+	}
+
+	store, err := _self.metaStore()
+	if err != nil {
+		return err
+	}
+	return store.update(func(meta *walletMeta) error {
+		rates := &meta.MovementRates
+		if key.imported {
+			rates = &meta.ImportedMovementRates
+		}
+		if *rates == nil {
+			*rates = make(map[uint32]MovementRate)
+		}
+		(*rates)[key.id] = MovementRate{Currency: currency, Rate: rate}
+		return nil
+	})
+}
@@ -0,0 +1,71 @@
+package bark
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// aspLimiter spaces out calls to the ASP so that no more than rate of them
+// start per second. Callers over the limit wait their turn in arrival order.
+type aspLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func (l *aspLimiter) setRate(perSec float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if perSec <= 0 {
+		l.interval = 0
+		return
+	}
+	l.interval = time.Duration(float64(time.Second) / perSec)
+}
+
+func (l *aspLimiter) wait() {
+	l.mu.Lock()
+	if l.interval == 0 {
+		l.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	time.Sleep(time.Until(slot))
+}
+
+// SetMaxAspRequestsPerSec limits how often the wallet calls out to the ASP.
+// Calls over the limit are not rejected; they block until their turn, in
+// the order they arrived, so a burst of n calls takes about n/perSec
+// seconds to get through. That wait comes on top of the time the call itself
+// takes. Pass 0 to remove the limit, which is the default. The setting is
+// persisted with the wallet.
+//
+// The limit applies per wallet method, and one method may make several
+// requests to the ASP.
+func (_self *Wallet) SetMaxAspRequestsPerSec(perSec float64) error {
+	if perSec < 0 || math.IsNaN(perSec) || math.IsInf(perSec, 0) {
+		return fmt.Errorf("invalid ASP request rate %v", perSec)
+	}
+	store, err := _self.metaStore()
+	if err != nil {
+		return err
+	}
+	err = store.update(func(meta *walletMeta) error {
+		meta.MaxAspRequestsPerSec = perSec
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	_self.state.aspLimiter.setRate(perSec)
+	return nil
+}
@@ -0,0 +1,122 @@
+package bark
+
+// SetAutoRefreshWithinBlocks makes Sync and Maintenance refresh the wallet's
+// vtxos whenever one of them is within blocks of its expiry height. Pass nil
+// to turn automatic refreshes off, which is the default. The setting is
+// persisted with the wallet.
+//
+// The native library can only refresh all vtxos at once, so when any vtxo is
+// at risk every vtxo is refreshed.
+func (_self *Wallet) SetAutoRefreshWithinBlocks(blocks *uint32) error {
+	store, err := _self.metaStore()
+	if err != nil {
+		return err
+	}
+	return store.update(func(meta *walletMeta) error {
+		meta.AutoRefreshWithinBlocks = blocks
+		return nil
+	})
+}
+
+// LastAutoRefresh returns the number of vtxos refreshed automatically by the
+// last Sync or Maintenance call.
+func (_self *Wallet) LastAutoRefresh() uint32 {
+	return _self.state.lastAutoRefresh.Load()
+}
+
+func (_self *Wallet) autoRefreshWithinBlocks() (uint32, bool) {
+	store, err := _self.metaStore()
+	if err != nil {
+		return 0, false
+	}
+	var blocks *uint32
+	store.view(func(meta *walletMeta) {
+		blocks = meta.AutoRefreshWithinBlocks
+	})
+	if blocks == nil {
+		return 0, false
+	}
+	return *blocks, true
+}
+
+// RefreshProgress reports how far RefreshAllWithProgress has got.
+type RefreshProgress struct {
+	Refreshed uint32
+	Total     uint32
+}
+
+// RefreshResult summarizes a completed refresh.
+type RefreshResult struct {
+	Vtxos     uint32
+	AmountSat uint64
+	// FeesSat is the sum of fees of the movements the refresh recorded.
+	FeesSat uint64
+}
+
+// RefreshAllWithProgress runs RefreshAll, reporting progress to cb before
+// and after, and returns what was refreshed. The native refresh handles all
+// vtxos in one round, so there are no intermediate progress reports, and the
+// round id is not available.
+func (_self *Wallet) RefreshAllWithProgress(cb func(RefreshProgress)) (RefreshResult, error) {
+	vtxos, err := _self.Vtxos()
+	if err != nil {
+		return RefreshResult{}, err
+	}
+	before, err := _self.Movements()
+	if err != nil {
+		return RefreshResult{}, err
+	}
+
+	result := RefreshResult{Vtxos: uint32(len(vtxos))}
+	for _, vtxo := range vtxos {
+		result.AmountSat += vtxo.AmountSat
+	}
+	cb(RefreshProgress{Refreshed: 0, Total: result.Vtxos})
+
+	if err := _self.RefreshAll(); err != nil {
+		return RefreshResult{}, err
+	}
+
+	after, err := _self.Movements()
+	if err != nil {
+		return RefreshResult{}, err
+	}
+	for _, movement := range newMovements(before, after) {
+		result.FeesSat += movement.FeesSat
+	}
+	cb(RefreshProgress{Refreshed: result.Vtxos, Total: result.Vtxos})
+	return result, nil
+}
+
+// RefreshExpiring refreshes the wallet's vtxos if any of them is within
+// withinBlocks of its expiry height at the current tip, and returns the
+// vtxos that were refreshed. If none is, nothing is refreshed and an empty
+// slice is returned.
+//
+// The native library can only refresh all vtxos at once, so when any vtxo
+// qualifies every vtxo is refreshed and returned, not only those near
+// expiry.
+func (_self *Wallet) RefreshExpiring(withinBlocks uint32) ([]Vtxo, error) {
+	height, err := _self.currentHeight()
+	if err != nil {
+		return nil, err
+	}
+	vtxos, err := _self.Vtxos()
+	if err != nil {
+		return nil, err
+	}
+	return refreshExpiring(vtxos, height, withinBlocks, _self.RefreshAll)
+}
+
+// refreshExpiring calls refreshAll and returns vtxos if any of them is
+// within withinBlocks of expiry at height, and otherwise returns an empty
+// slice without refreshing.
+func refreshExpiring(vtxos []Vtxo, height uint32, withinBlocks uint32, refreshAll func() error) ([]Vtxo, error) {
+	if len(expiringVtxos(vtxos, height, withinBlocks)) == 0 {
+		return []Vtxo{}, nil
+	}
+	if err := refreshAll(); err != nil {
+		return nil, err
+	}
+	return vtxos, nil
+}
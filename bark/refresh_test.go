@@ -0,0 +1,78 @@
+package bark
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// countRefreshes returns a refreshAll func that counts its calls.
+func countRefreshes(calls *int, err error) func() error {
+	return func() error {
+		*calls++
+		return err
+	}
+}
+
+func TestRefreshExpiringNoneQualify(t *testing.T) {
+	vtxos := []Vtxo{
+		{AmountSat: 1_000, ExpiryHeight: 1_200},
+		{AmountSat: 2_000, ExpiryHeight: 1_500},
+	}
+	for _, vtxos := range [][]Vtxo{nil, vtxos} {
+		var calls int
+		refreshed, err := refreshExpiring(vtxos, 1_000, 100, countRefreshes(&calls, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if refreshed == nil || len(refreshed) != 0 {
+			t.Errorf("refreshed = %#v, want an empty, non-nil slice", refreshed)
+		}
+		if calls != 0 {
+			t.Errorf("refreshed %d times, want none", calls)
+		}
+	}
+}
+
+func TestRefreshExpiringOneQualifies(t *testing.T) {
+	vtxos := []Vtxo{
+		{AmountSat: 1_000, ExpiryHeight: 1_050},
+		{AmountSat: 2_000, ExpiryHeight: 1_500},
+		{AmountSat: 3_000, ExpiryHeight: 2_000},
+	}
+	var calls int
+	refreshed, err := refreshExpiring(vtxos, 1_000, 100, countRefreshes(&calls, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("refreshed %d times, want once", calls)
+	}
+	// The native library refreshes every vtxo, so all are returned.
+	if !reflect.DeepEqual(refreshed, vtxos) {
+		t.Errorf("refreshed = %+v, want %+v", refreshed, vtxos)
+	}
+}
+
+func TestRefreshExpiringWithinIsInclusive(t *testing.T) {
+	vtxos := []Vtxo{{AmountSat: 1_000, ExpiryHeight: 1_100}}
+	var calls int
+	if _, err := refreshExpiring(vtxos, 1_000, 100, countRefreshes(&calls, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("a vtxo exactly withinBlocks from expiry was not refreshed")
+	}
+}
+
+func TestRefreshExpiringFailure(t *testing.T) {
+	vtxos := []Vtxo{{AmountSat: 1_000, ExpiryHeight: 900}}
+	var calls int
+	refreshed, err := refreshExpiring(vtxos, 1_000, 100, countRefreshes(&calls, ErrErrorInsufficientFunds))
+	if !errors.Is(err, ErrErrorInsufficientFunds) {
+		t.Fatalf("error = %v, want the refresh error", err)
+	}
+	if refreshed != nil {
+		t.Errorf("refreshed = %+v after a failed refresh, want nil", refreshed)
+	}
+}
@@ -0,0 +1,77 @@
+package bark
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// openWallets holds the resolved paths of the wallets open in this process.
+var openWallets = struct {
+	sync.Mutex
+	paths map[string]struct{}
+}{paths: make(map[string]struct{})}
+
+// OpenWalletPaths returns the resolved paths of the wallets currently open in
+// this process, sorted.
+func OpenWalletPaths() []string {
+	openWallets.Lock()
+	defer openWallets.Unlock()
+	paths := make([]string, 0, len(openWallets.paths))
+	for path := range openWallets.paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// registerWallet claims path for a wallet about to be opened and returns
+// the key to release it with. It fails with ErrErrorWalletAlreadyOpen if
+// another wallet in this process has the same path open.
+func registerWallet(path string) (string, error) {
+	key := resolveWalletPath(path)
+	openWallets.Lock()
+	defer openWallets.Unlock()
+	if _, ok := openWallets.paths[key]; ok {
+		return "", fmt.Errorf("%w: %s", ErrErrorWalletAlreadyOpen, key)
+	}
+	openWallets.paths[key] = struct{}{}
+	return key, nil
+}
+
+func unregisterWallet(key string) {
+	openWallets.Lock()
+	defer openWallets.Unlock()
+	delete(openWallets.paths, key)
+}
+
+// release is called as each handle sharing s is destroyed. Once the last
+// one is gone it removes the observers and gives up the wallet's claim on
+// its path.
+func (s *walletState) release() {
+	if s.handles.Add(-1) >= 0 {
+		return
+	}
+	s.observers.closeAll()
+	if s.registryKey != "" && s.released.CompareAndSwap(false, true) {
+		unregisterWallet(s.registryKey)
+	}
+}
+
+// resolveWalletPath makes path absolute and resolves symlinks, so different
+// spellings of the same location give the same key. A path that does not
+// exist yet is resolved through its parent directory.
+func resolveWalletPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = filepath.Clean(path)
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved
+	}
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(abs)); err == nil {
+		return filepath.Join(dir, filepath.Base(abs))
+	}
+	return abs
+}
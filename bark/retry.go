@@ -0,0 +1,75 @@
+package bark
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy controls SyncWithRetry.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first. Zero
+	// or less means a single attempt.
+	MaxAttempts int
+	// InitialBackoff is the wait before the second attempt. It doubles for
+	// each attempt after that, up to MaxBackoff.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts. Zero means no cap.
+	MaxBackoff time.Duration
+}
+
+// SyncWithRetry runs SyncContext until it succeeds, retrying failures that
+// are likely transient: ErrErrorBarkFailed from the native library and
+// ErrErrorEsploraFailed from the Go layer's chain queries. Any other error
+// is returned straight away. Each wait is the backoff with up to half of it
+// taken off at random, so many wallets retrying together spread out.
+//
+// It returns ctx.Err() if ctx is done during a wait, and otherwise the last
+// attempt's error once MaxAttempts is used up.
+func (_self *Wallet) SyncWithRetry(ctx context.Context, policy RetryPolicy) error {
+	return retry(ctx, policy, _self.SyncContext)
+}
+
+// retry runs call as SyncWithRetry runs SyncContext.
+func retry(ctx context.Context, policy RetryPolicy, call func(context.Context) error) error {
+	backoff := policy.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := call(ctx)
+		if err == nil || attempt >= policy.MaxAttempts || !retryable(err) {
+			return err
+		}
+
+		wait := backoff
+		if wait > 0 {
+			wait -= rand.N(wait/2 + 1)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		backoff = nextBackoff(backoff, policy.MaxBackoff)
+	}
+}
+
+// nextBackoff doubles backoff up to maxBackoff, or without a cap if
+// maxBackoff is zero. Without a cap it stops doubling before it would
+// overflow.
+func nextBackoff(backoff, maxBackoff time.Duration) time.Duration {
+	if backoff <= math.MaxInt64/2 {
+		backoff *= 2
+	}
+	if maxBackoff > 0 && backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+func retryable(err error) bool {
+	return errors.Is(err, ErrErrorBarkFailed) || errors.Is(err, ErrErrorEsploraFailed)
+}
@@ -0,0 +1,97 @@
+package bark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// failingCall returns a call that fails with err for the first failures
+// attempts and then succeeds, and a pointer to the number of attempts.
+func failingCall(failures int, err error) (func(context.Context) error, *int) {
+	attempts := 0
+	return func(context.Context) error {
+		attempts++
+		if attempts <= failures {
+			return err
+		}
+		return nil
+	}, &attempts
+}
+
+func TestRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}
+	tests := []struct {
+		name         string
+		failures     int
+		err          error
+		wantErr      error
+		wantAttempts int
+	}{
+		{"succeeds first time", 0, NewErrorBarkFailed(), nil, 1},
+		{"bark failure then success", 3, NewErrorBarkFailed(), nil, 4},
+		{"esplora failure then success", 2, fmt.Errorf("%w: timeout", ErrErrorEsploraFailed), nil, 3},
+		{"gives up after MaxAttempts", 10, NewErrorBarkFailed(), ErrErrorBarkFailed, 5},
+		{"deterministic error", 10, NewErrorInvalidNetwork(), ErrErrorInvalidNetwork, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			call, attempts := failingCall(tt.failures, tt.err)
+			err := retry(context.Background(), policy, call)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if *attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", *attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryZeroMaxAttempts(t *testing.T) {
+	call, attempts := failingCall(1, NewErrorBarkFailed())
+	if err := retry(context.Background(), RetryPolicy{}, call); !errors.Is(err, ErrErrorBarkFailed) {
+		t.Errorf("err = %v, want ErrErrorBarkFailed", err)
+	}
+	if *attempts != 1 {
+		t.Errorf("attempts = %d, want 1", *attempts)
+	}
+}
+
+func TestRetryCancelledDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	call := func(context.Context) error {
+		attempts++
+		cancel()
+		return NewErrorBarkFailed()
+	}
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour}
+	if err := retry(ctx, policy, call); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestNextBackoffLargeMaxAttempts(t *testing.T) {
+	for _, maxBackoff := range []time.Duration{0, time.Minute} {
+		backoff := time.Millisecond
+		for attempt := 2; attempt <= 1_000; attempt++ {
+			next := nextBackoff(backoff, maxBackoff)
+			if next < backoff {
+				t.Fatalf("max %v, attempt %d: backoff went from %v to %v", maxBackoff, attempt, backoff, next)
+			}
+			if maxBackoff > 0 && next > maxBackoff {
+				t.Fatalf("max %v, attempt %d: backoff %v over the cap", maxBackoff, attempt, next)
+			}
+			backoff = next
+		}
+		if want := maxBackoff; want > 0 && backoff != want {
+			t.Errorf("backoff settled at %v, want %v", backoff, want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package bark
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// satsPerBtc is the number of sats in one bitcoin.
+const satsPerBtc = 100_000_000
+
+// Sat is an amount in satoshis. The wallet's methods take and return plain
+// uint64 sats; Sat is a helper for converting and formatting them.
+type Sat uint64
+
+// Btc returns the amount in bitcoin.
+func (s Sat) Btc() float64 {
+	return float64(s) / satsPerBtc
+}
+
+// Msat returns the amount in millisatoshis. It does not check for overflow,
+// which needs an amount far beyond the bitcoin supply.
+func (s Sat) Msat() uint64 {
+	return uint64(s) * 1000
+}
+
+// String formats the amount with thousands separators, as in "1,234 sat".
+func (s Sat) String() string {
+	digits := strconv.FormatUint(uint64(s), 10)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	b.WriteString(" sat")
+	return b.String()
+}
+
+// FromBtc converts an amount in bitcoin to sats. It fails with
+// ErrErrorInvalidAmount if btc is negative, not finite or has more than
+// eight decimal places. The check uses the shortest decimal form of btc, so
+// 0.1 is exactly 10,000,000 sat despite its binary representation.
+func FromBtc(btc float64) (Sat, error) {
+	if btc < 0 || math.IsNaN(btc) || math.IsInf(btc, 0) {
+		return 0, fmt.Errorf("%w: %v BTC", ErrErrorInvalidAmount, btc)
+	}
+	whole, fraction, _ := strings.Cut(strconv.FormatFloat(btc, 'f', -1, 64), ".")
+	if len(fraction) > 8 {
+		return 0, fmt.Errorf("%w: %v BTC is not a whole number of sats", ErrErrorInvalidAmount, btc)
+	}
+	fraction += strings.Repeat("0", 8-len(fraction))
+	sats, err := strconv.ParseUint(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v BTC is out of range", ErrErrorInvalidAmount, btc)
+	}
+	return Sat(sats), nil
+}
+
+// ParseSat parses a whole number of sats, as written by String or as plain
+// digits; the " sat" suffix is optional. Thousands separators are only
+// accepted where String puts them, so "1,234" parses but "1,2,3,4" does not.
+func ParseSat(s string) (Sat, error) {
+	trimmed := strings.TrimSpace(s)
+	trimmed = strings.TrimSpace(strings.TrimSuffix(trimmed, "sat"))
+	if strings.Contains(trimmed, ",") {
+		groups := strings.Split(trimmed, ",")
+		if len(groups[0]) == 0 || len(groups[0]) > 3 || groups[0][0] == '0' {
+			return 0, fmt.Errorf("%w: %q", ErrErrorInvalidAmount, s)
+		}
+		for _, group := range groups[1:] {
+			if len(group) != 3 {
+				return 0, fmt.Errorf("%w: %q", ErrErrorInvalidAmount, s)
+			}
+		}
+		trimmed = strings.Join(groups, "")
+	}
+	sats, err := strconv.ParseUint(trimmed, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrErrorInvalidAmount, s)
+	}
+	return Sat(sats), nil
+}
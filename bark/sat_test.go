@@ -0,0 +1,139 @@
+package bark
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFromBtc(t *testing.T) {
+	tests := []struct {
+		btc     float64
+		want    Sat
+		wantErr bool
+	}{
+		{0, 0, false},
+		{0.00000001, 1, false},
+		{0.1, 10_000_000, false},
+		{0.29, 29_000_000, false},
+		{0.99999999, 99_999_999, false},
+		{1, satsPerBtc, false},
+		{1.00000001, 100_000_001, false},
+		{1.23456789, 123_456_789, false},
+		{21_000_000, 2_100_000_000_000_000, false},
+		{0.000000001, 0, true},
+		{0.000000015, 0, true},
+		{1.000000001, 0, true},
+		{-0.00000001, 0, true},
+		{1e12, 0, true},
+		{math.NaN(), 0, true},
+		{math.Inf(1), 0, true},
+	}
+	for _, tt := range tests {
+		got, err := FromBtc(tt.btc)
+		if tt.wantErr {
+			if !errors.Is(err, ErrErrorInvalidAmount) {
+				t.Errorf("FromBtc(%v) error = %v, want ErrErrorInvalidAmount", tt.btc, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("FromBtc(%v): %v", tt.btc, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("FromBtc(%v) = %d, want %d", tt.btc, got, tt.want)
+		}
+	}
+}
+
+func TestSatBtcRoundTrip(t *testing.T) {
+	for _, sats := range []Sat{0, 1, 9, 10, 99_999_999, satsPerBtc, satsPerBtc + 1, 123_456_789, 2_100_000_000_000_000} {
+		back, err := FromBtc(sats.Btc())
+		if err != nil {
+			t.Errorf("FromBtc(Sat(%d).Btc()): %v", sats, err)
+			continue
+		}
+		if back != sats {
+			t.Errorf("FromBtc(Sat(%d).Btc()) = %d", sats, back)
+		}
+	}
+}
+
+func TestSatMsat(t *testing.T) {
+	if got := Sat(1234).Msat(); got != 1_234_000 {
+		t.Errorf("Sat(1234).Msat() = %d, want 1234000", got)
+	}
+}
+
+func TestSatString(t *testing.T) {
+	tests := []struct {
+		sats Sat
+		want string
+	}{
+		{0, "0 sat"},
+		{999, "999 sat"},
+		{1000, "1,000 sat"},
+		{1234, "1,234 sat"},
+		{100_000_000, "100,000,000 sat"},
+		{math.MaxUint64, "18,446,744,073,709,551,615 sat"},
+	}
+	for _, tt := range tests {
+		if got := tt.sats.String(); got != tt.want {
+			t.Errorf("Sat(%d).String() = %q, want %q", uint64(tt.sats), got, tt.want)
+		}
+	}
+}
+
+func TestParseSat(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    Sat
+		wantErr bool
+	}{
+		{"0", 0, false},
+		{"1234", 1234, false},
+		{"1,234", 1234, false},
+		{"1,234 sat", 1234, false},
+		{" 100,000,000 sat ", 100_000_000, false},
+		{"999sat", 999, false},
+		{"18,446,744,073,709,551,615 sat", math.MaxUint64, false},
+		{"1,2,3,4 sat", 0, true},
+		{"12,34", 0, true},
+		{"1234,567", 0, true},
+		{",123", 0, true},
+		{"1,", 0, true},
+		{"0,123", 0, true},
+		{"1,,234", 0, true},
+		{"-1", 0, true},
+		{"1.5", 0, true},
+		{"", 0, true},
+		{"sat", 0, true},
+		{"18,446,744,073,709,551,616", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSat(tt.s)
+		if tt.wantErr {
+			if !errors.Is(err, ErrErrorInvalidAmount) {
+				t.Errorf("ParseSat(%q) = %d, %v, want ErrErrorInvalidAmount", tt.s, got, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseSat(%q): %v", tt.s, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSat(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestParseSatRoundTrip(t *testing.T) {
+	for _, sats := range []Sat{0, 7, 1000, 1_234_567, math.MaxUint64} {
+		got, err := ParseSat(sats.String())
+		if err != nil || got != sats {
+			t.Errorf("ParseSat(%q) = %d, %v, want %d", sats.String(), got, err, sats)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package bark
+
+import (
+	"sync"
+	"time"
+)
+
+// StartMaintenance runs Sync followed by Maintenance every interval in a
+// goroutine until the returned stop function is called. The first run is
+// one interval after the call. Failures are logged to the Logger set with
+// SetLogger, and Maintenance is skipped when the Sync before it fails.
+//
+// stop waits for a run in progress to finish and is safe to call more than
+// once. The scheduler holds its own Handle, so the wallet stays open until
+// stop is called. interval must be positive: otherwise nothing is started,
+// the error is logged and stop does nothing.
+func (_self *Wallet) StartMaintenance(interval time.Duration) (stop func()) {
+	if interval <= 0 {
+		if l := logger.Load(); l != nil {
+			(*l).Errorf("bark: StartMaintenance: interval %s is not positive", interval)
+		}
+		return func() {}
+	}
+	wallet := _self.Handle()
+	ticker := time.NewTicker(interval)
+	return runOnTicks(ticker.C, func() {
+		// Sync and Maintenance log their own failures.
+		if wallet.Sync() == nil {
+			_ = wallet.Maintenance()
+		}
+	}, func() {
+		ticker.Stop()
+		wallet.Destroy()
+	})
+}
+
+// runOnTicks calls run for every value from tick in a goroutine until the
+// returned stop function is called, then calls cleanup. stop waits for the
+// goroutine to exit and is safe to call more than once.
+func runOnTicks(tick <-chan time.Time, run func(), cleanup func()) (stop func()) {
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		defer cleanup()
+		for {
+			select {
+			case <-done:
+				return
+			case <-tick:
+			}
+			run()
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+		<-exited
+	}
+}
@@ -0,0 +1,81 @@
+package bark
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunOnTicks(t *testing.T) {
+	tick := make(chan time.Time)
+	var runs atomic.Int32
+	var cleanups atomic.Int32
+	stop := runOnTicks(tick, func() { runs.Add(1) }, func() { cleanups.Add(1) })
+
+	const n = 5
+	for range n {
+		tick <- time.Now()
+	}
+	stop()
+	stop()
+	// Every tick taken is run before stop returns.
+	if got := runs.Load(); got != n {
+		t.Errorf("runs = %d, want %d", got, n)
+	}
+	if got := cleanups.Load(); got != 1 {
+		t.Errorf("cleanups = %d, want 1", got)
+	}
+
+	// Ticks after stop start no runs.
+	select {
+	case tick <- time.Now():
+		t.Error("tick accepted after stop")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestRunOnTicksStopWaitsForRun(t *testing.T) {
+	tick := make(chan time.Time)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var finished atomic.Bool
+	stop := runOnTicks(tick, func() {
+		close(started)
+		<-release
+		finished.Store(true)
+	}, func() {})
+
+	tick <- time.Now()
+	<-started
+	stopped := make(chan struct{})
+	go func() {
+		stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		t.Fatal("stop returned while a run was in progress")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(release)
+	<-stopped
+	if !finished.Load() {
+		t.Error("stop returned before the run finished")
+	}
+}
+
+func TestStartMaintenanceRejectsNonPositiveInterval(t *testing.T) {
+	l := setTestLogger(t)
+	wallet, native := newTestWallet(t)
+	for _, interval := range []time.Duration{0, -time.Second} {
+		stop := wallet.StartMaintenance(interval)
+		stop()
+	}
+	if native.refs.Load() != 1 {
+		t.Errorf("native refs = %d, want 1: no handle should be taken", native.refs.Load())
+	}
+	if len(l.errors) != 2 || !strings.Contains(l.errors[0], "interval 0s is not positive") {
+		t.Errorf("error lines = %q, want one per rejected interval", l.errors)
+	}
+}
@@ -0,0 +1,198 @@
+package bark
+
+import (
+	"fmt"
+	"math"
+)
+
+// DustLimitSat is the smallest amount, in sats, of a vtxo or onchain output.
+const DustLimitSat = 330
+
+// SendSplit sends the given amounts to destination as separate vtxos, so the
+// recipient gets one vtxo per amount instead of a single large one. Every
+// amount must be at least DustLimitSat and their sum must be covered by the
+// spendable balance.
+//
+// Each amount is a separate Send. If one fails, the vtxos from the sends
+// that already went through are returned along with the error.
+func (_self *Wallet) SendSplit(destination BarkAddress, amounts []uint64) ([]Vtxo, error) {
+	if len(amounts) == 0 {
+		return nil, fmt.Errorf("%w: no amounts given", ErrErrorInvalidAmount)
+	}
+	var total uint64
+	for i, amount := range amounts {
+		if amount < DustLimitSat {
+			return nil, fmt.Errorf("%w: amount %d (%d sat) is below the dust limit of %d sat",
+				ErrErrorInvalidAmount, i, amount, DustLimitSat)
+		}
+		if amount > math.MaxUint64-total {
+			return nil, fmt.Errorf("%w: amounts overflow", ErrErrorInvalidAmount)
+		}
+		total += amount
+	}
+
+	balance, err := _self.WalletBalance()
+	if err != nil {
+		return nil, err
+	}
+	if total > balance.SpendableSat {
+		return nil, fmt.Errorf("%w: sending %d sat, %d sat spendable",
+			ErrErrorInsufficientFunds, total, balance.SpendableSat)
+	}
+
+	var sent []Vtxo
+	for _, amount := range amounts {
+		vtxos, err := _self.Send(destination, amount)
+		if err != nil {
+			return sent, err
+		}
+		sent = append(sent, vtxos...)
+	}
+	return sent, nil
+}
+
+// Send sends amountSats to the Ark address destination. It fails with
+// ErrErrorAmountExceedsMaxVtxo if the ASP caps vtxos below amountSats; use
+// SendSplit to send such an amount in parts.
+func (_self *Wallet) Send(destination BarkAddress, amountSats uint64) (vtxos []Vtxo, err error) {
+	defer logCall("Send", "amount_sats", amountSats)(&err)
+	if err := _self.checkSend(destination, amountSats); err != nil {
+		return nil, err
+	}
+	release, err := _self.reserveSend(amountSats)
+	if err != nil {
+		return nil, err
+	}
+	vtxos, err = _self.sendNative(destination, amountSats)
+	if err != nil {
+		release()
+	}
+	return vtxos, err
+}
+
+// SendOnchain sends amountSats to the onchain address and returns the txid.
+func (_self *Wallet) SendOnchain(address string, amountSats uint64) (txid string, err error) {
+	defer logCall("SendOnchain", "amount_sats", amountSats)(&err)
+	if err := _self.checkDestination(address); err != nil {
+		return "", err
+	}
+	release, err := _self.reserveSend(amountSats)
+	if err != nil {
+		return "", err
+	}
+	txid, err = _self.sendOnchainNative(address, amountSats)
+	if err != nil {
+		release()
+	}
+	return txid, err
+}
+
+// checkSend checks a single Ark payment before it is made, as Send,
+// SendPreview and SendMany do: destination must be an Ark address allowed
+// by the send allowlist, and amountSats at least DustLimitSat and within the
+// ASP's maximum vtxo size. It fails with ErrErrorInvalidBarkAddress,
+// ErrErrorInvalidAmount, ErrErrorDestinationNotAllowed or
+// ErrErrorAmountExceedsMaxVtxo.
+func (_self *Wallet) checkSend(destination BarkAddress, amountSats uint64) error {
+	if !isArkAddress(normalizeDestination(destination)) {
+		return fmt.Errorf("%w: %s", ErrErrorInvalidBarkAddress, destination)
+	}
+	if amountSats < DustLimitSat {
+		return fmt.Errorf("%w: %d sat is below the dust limit of %d sat",
+			ErrErrorInvalidAmount, amountSats, DustLimitSat)
+	}
+	if err := _self.checkDestination(destination); err != nil {
+		return err
+	}
+	return _self.checkMaxVtxoAmount(amountSats)
+}
+
+// checkMaxVtxoAmount fails with ErrErrorAmountExceedsMaxVtxo if amountSats
+// does not fit in a single vtxo under the ASP's MaxVtxoAmountSats. If the
+// ark info cannot be fetched the check is left to the native library.
+func (_self *Wallet) checkMaxVtxoAmount(amountSats uint64) error {
+	info, err := _self.ArkInfo()
+	if err != nil || info.MaxVtxoAmountSats == nil {
+		return nil
+	}
+	max := *info.MaxVtxoAmountSats
+	if amountSats <= max {
+		return nil
+	}
+	parts := (amountSats + max - 1) / max
+	return fmt.Errorf("%w: %d sat exceeds the ASP maximum of %d sat per vtxo; split it into at least %d parts",
+		ErrErrorAmountExceedsMaxVtxo, amountSats, max, parts)
+}
+
+// SendOutput is one payment of a SendMany.
+type SendOutput struct {
+	Destination BarkAddress
+	AmountSats  uint64
+}
+
+// SendMany pays every output. All outputs are checked before anything is
+// sent: each destination must be a valid, allowed Ark address and each
+// amount at least DustLimitSat and within the ASP's maximum vtxo size, and
+// the total must fit the spendable balance and the daily send limit. If any
+// check fails, nothing is sent.
+//
+// The native library has no batch send, so the outputs are paid with one
+// Send each, in order. Partial success is therefore possible: if a send
+// fails, the vtxos from the sends that already went through are returned
+// along with the error.
+func (_self *Wallet) SendMany(outputs []SendOutput) ([]Vtxo, error) {
+	return sendMany(outputs, _self.checkSend, _self.checkSendTotal, _self.Send)
+}
+
+// sendMany does the work of SendMany: every output is checked with check
+// and their total with checkTotal before any is paid with send.
+func sendMany(
+	outputs []SendOutput,
+	check func(destination BarkAddress, amountSats uint64) error,
+	checkTotal func(totalSats uint64) error,
+	send func(destination BarkAddress, amountSats uint64) ([]Vtxo, error),
+) ([]Vtxo, error) {
+	if len(outputs) == 0 {
+		return nil, fmt.Errorf("%w: no outputs given", ErrErrorInvalidAmount)
+	}
+	var total uint64
+	for i, output := range outputs {
+		if err := check(output.Destination, output.AmountSats); err != nil {
+			return nil, fmt.Errorf("output %d: %w", i, err)
+		}
+		if output.AmountSats > math.MaxUint64-total {
+			return nil, fmt.Errorf("%w: amounts overflow", ErrErrorInvalidAmount)
+		}
+		total += output.AmountSats
+	}
+	if err := checkTotal(total); err != nil {
+		return nil, err
+	}
+
+	var sent []Vtxo
+	for i, output := range outputs {
+		vtxos, err := send(output.Destination, output.AmountSats)
+		if err != nil {
+			return sent, fmt.Errorf("output %d: %w", i, err)
+		}
+		sent = append(sent, vtxos...)
+	}
+	return sent, nil
+}
+
+// checkSendTotal checks that totalSats fits the daily send limit and the
+// spendable balance.
+func (_self *Wallet) checkSendTotal(totalSats uint64) error {
+	if err := _self.checkSendAllowance(totalSats); err != nil {
+		return err
+	}
+	balance, err := _self.WalletBalance()
+	if err != nil {
+		return err
+	}
+	if totalSats > balance.SpendableSat {
+		return fmt.Errorf("%w: sending %d sat, %d sat spendable",
+			ErrErrorInsufficientFunds, totalSats, balance.SpendableSat)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package bark
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// checkArkAddress stands in for Wallet.checkSend without the ASP's
+// maximum vtxo size, which needs the native library.
+func checkArkAddress(destination BarkAddress, amountSats uint64) error {
+	if !isArkAddress(normalizeDestination(destination)) {
+		return fmt.Errorf("%w: %s", ErrErrorInvalidBarkAddress, destination)
+	}
+	return nil
+}
+
+func acceptTotal(uint64) error { return nil }
+
+// recordSends returns a send func that records its payments and pays each
+// with a single vtxo of the amount sent.
+func recordSends(sent *[]SendOutput) func(BarkAddress, uint64) ([]Vtxo, error) {
+	return func(destination BarkAddress, amountSats uint64) ([]Vtxo, error) {
+		*sent = append(*sent, SendOutput{Destination: destination, AmountSats: amountSats})
+		return []Vtxo{{AmountSat: amountSats}}, nil
+	}
+}
+
+func TestSendManyEmpty(t *testing.T) {
+	wallet, _ := newTestWallet(t)
+	for _, outputs := range [][]SendOutput{nil, {}} {
+		if _, err := wallet.SendMany(outputs); !errors.Is(err, ErrErrorInvalidAmount) {
+			t.Errorf("SendMany(%#v) error = %v, want ErrErrorInvalidAmount", outputs, err)
+		}
+	}
+}
+
+func TestSendManyInvalidAddressInMiddle(t *testing.T) {
+	outputs := []SendOutput{
+		{Destination: testArkAddress(1), AmountSats: 1_000},
+		{Destination: "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", AmountSats: 2_000},
+		{Destination: testArkAddress(3), AmountSats: 3_000},
+	}
+	var sent []SendOutput
+	vtxos, err := sendMany(outputs, checkArkAddress, acceptTotal, recordSends(&sent))
+	if !errors.Is(err, ErrErrorInvalidBarkAddress) {
+		t.Fatalf("error = %v, want ErrErrorInvalidBarkAddress", err)
+	}
+	if !strings.HasPrefix(err.Error(), "output 1: ") {
+		t.Errorf("error %q does not name output 1", err)
+	}
+	if len(sent) != 0 || vtxos != nil {
+		t.Errorf("sent %v and got vtxos %v, want nothing sent", sent, vtxos)
+	}
+}
+
+func TestSendManyTotalChecked(t *testing.T) {
+	outputs := []SendOutput{
+		{Destination: testArkAddress(1), AmountSats: 1_000},
+		{Destination: testArkAddress(2), AmountSats: 2_000},
+	}
+	var checked uint64
+	checkTotal := func(total uint64) error {
+		checked = total
+		return ErrErrorInsufficientFunds
+	}
+	var sent []SendOutput
+	if _, err := sendMany(outputs, checkArkAddress, checkTotal, recordSends(&sent)); !errors.Is(err, ErrErrorInsufficientFunds) {
+		t.Fatalf("error = %v, want ErrErrorInsufficientFunds", err)
+	}
+	if checked != 3_000 {
+		t.Errorf("total checked = %d, want 3000", checked)
+	}
+	if len(sent) != 0 {
+		t.Errorf("sent %v after the total check failed", sent)
+	}
+}
+
+func TestSendManyTwoOutputs(t *testing.T) {
+	outputs := []SendOutput{
+		{Destination: testArkAddress(1), AmountSats: 1_000},
+		{Destination: testArkAddress(2), AmountSats: 2_000},
+	}
+	var sent []SendOutput
+	vtxos, err := sendMany(outputs, checkArkAddress, acceptTotal, recordSends(&sent))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sent, outputs) {
+		t.Errorf("sent %v, want %v in order", sent, outputs)
+	}
+	if want := []Vtxo{{AmountSat: 1_000}, {AmountSat: 2_000}}; !reflect.DeepEqual(vtxos, want) {
+		t.Errorf("vtxos = %v, want %v", vtxos, want)
+	}
+}
+
+func TestSendManyPartialFailure(t *testing.T) {
+	outputs := []SendOutput{
+		{Destination: testArkAddress(1), AmountSats: 1_000},
+		{Destination: testArkAddress(2), AmountSats: 2_000},
+	}
+	var sent []SendOutput
+	record := recordSends(&sent)
+	send := func(destination BarkAddress, amountSats uint64) ([]Vtxo, error) {
+		if len(sent) == 1 {
+			return nil, ErrErrorInsufficientFunds
+		}
+		return record(destination, amountSats)
+	}
+	vtxos, err := sendMany(outputs, checkArkAddress, acceptTotal, send)
+	if !errors.Is(err, ErrErrorInsufficientFunds) {
+		t.Fatalf("error = %v, want ErrErrorInsufficientFunds", err)
+	}
+	if want := []Vtxo{{AmountSat: 1_000}}; !reflect.DeepEqual(vtxos, want) {
+		t.Errorf("vtxos = %v, want those of the first send %v", vtxos, want)
+	}
+}
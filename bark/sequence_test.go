@@ -0,0 +1,48 @@
+package bark
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestEmptySequencesReadAsEmptySlices(t *testing.T) {
+	// A sequence of length zero is a big-endian int32 0.
+	empty := func() *bytes.Reader { return bytes.NewReader([]byte{0, 0, 0, 0}) }
+	tests := []struct {
+		name string
+		read func() (any, bool)
+	}{
+		{"Movement", func() (any, bool) {
+			s := FfiConverterSequenceMovementINSTANCE.Read(empty())
+			return s, s != nil && len(s) == 0
+		}},
+		{"OnchainTransaction", func() (any, bool) {
+			s := FfiConverterSequenceOnchainTransactionINSTANCE.Read(empty())
+			return s, s != nil && len(s) == 0
+		}},
+		{"Vtxo", func() (any, bool) {
+			s := FfiConverterSequenceVtxoINSTANCE.Read(empty())
+			return s, s != nil && len(s) == 0
+		}},
+		{"Utxo", func() (any, bool) {
+			s := FfiConverterSequenceUtxoINSTANCE.Read(empty())
+			return s, s != nil && len(s) == 0
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := tt.read()
+			if !ok {
+				t.Fatalf("Read = %#v, want a non-nil empty slice", value)
+			}
+			data, err := json.Marshal(value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != "[]" {
+				t.Errorf("json.Marshal = %s, want []", data)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package bark
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+)
+
+// metaFileName is the name of the Go layer's metadata file when the wallet
+// path is a directory. For a file path the metadata lives next to it with
+// metaFileSuffix appended.
+const (
+	metaFileName   = "bark-go.json"
+	metaFileSuffix = ".bark-go.json"
+)
+
+// walletState holds everything the Go layer keeps about a wallet that the
+// native library has no place for. It is shared by every handle to the same
+// wallet.
+type walletState struct {
+	path string
+	meta *metaStore
+
+	// registryKey is the wallet's entry in the open wallet registry and
+	// released records that it has been given up.
+	registryKey string
+	released    atomic.Bool
+	// handles is the number of handles from Handle that have not been
+	// destroyed; the state is released with the last handle.
+	handles atomic.Int32
+
+	// labelMu serializes NewAddressLabeled so a label never gets two
+	// addresses.
+	labelMu sync.Mutex
+
+	// syncedHeight is the chain tip seen at the end of the last successful
+	// sync, zero if the wallet has not synced since it was opened.
+	syncedHeight atomic.Uint32
+	// lastAutoRefresh is the number of vtxos refreshed by the last sync.
+	lastAutoRefresh atomic.Uint32
+
+	expiry      expiryWatch
+	syncTimings syncTimings
+	aspLimiter  aspLimiter
+	// aspMismatch is set while the ASP's key differs from the pin, and
+	// aspChecked once the pin has been compared with the ASP's key at all.
+	aspMismatch atomic.Pointer[error]
+	aspChecked  atomic.Bool
+
+	observers observers
+}
+
+// attachWalletState binds the wallet returned by CreateWallet or OpenWallet
+// to its path and loads the persisted metadata. CreateWallet passes the
+// config it was created with so that wallets opened later can find their
+// chain source again.
+func attachWalletState(wallet *Wallet, path string, mnemonic string, config *Config) error {
+	meta, err := openMetaStore(path)
+	if err != nil {
+		return err
+	}
+	if err := recordMnemonicFingerprint(meta, mnemonic); err != nil {
+		return err
+	}
+	if config != nil {
+		err := meta.update(func(m *walletMeta) error {
+			m.Config = config
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	wallet.state.path = path
+	wallet.state.meta = meta
+	var dataDir *string
+	meta.view(func(m *walletMeta) {
+		wallet.state.aspLimiter.setRate(m.MaxAspRequestsPerSec)
+		if m.Config != nil {
+			dataDir = m.Config.DataDir
+		}
+	})
+	if dataDir != nil {
+		wallet.state.syncTimings.load(cacheFile(*dataDir, path, "sync-timings.json"))
+	}
+	return nil
+}
+
+// cacheFile returns the path of the named cache file for the wallet at
+// walletPath. Several wallets may share a data directory, so the name is
+// prefixed with a digest of the wallet path.
+func cacheFile(dataDir string, walletPath string, name string) string {
+	if abs, err := filepath.Abs(walletPath); err == nil {
+		walletPath = abs
+	}
+	digest := sha256.Sum256([]byte(walletPath))
+	return filepath.Join(dataDir, hex.EncodeToString(digest[:8])+"-"+name)
+}
+
+// checkDataDir creates dataDir if needed and makes sure files can be
+// written to it.
+func checkDataDir(dataDir string) error {
+	notAccessible := func(err error) error {
+		return &Error{&ErrorBarkDbFileNotAccessible{fmt.Sprintf("data dir %s: %v", dataDir, err)}}
+	}
+	if err := os.MkdirAll(dataDir, 0o700); err != nil {
+		return notAccessible(err)
+	}
+	probe, err := os.CreateTemp(dataDir, ".write-test-*")
+	if err != nil {
+		return notAccessible(err)
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+	return nil
+}
+
+// config returns the config the wallet was created with, if known.
+func (_self *Wallet) config() (Config, error) {
+	store, err := _self.metaStore()
+	if err != nil {
+		return Config{}, err
+	}
+	var config *Config
+	store.view(func(meta *walletMeta) {
+		config = meta.Config
+	})
+	if config == nil {
+		return Config{}, ErrErrorUnknownConfig
+	}
+	return *config, nil
+}
+
+func (_self *Wallet) metaStore() (*metaStore, error) {
+	if _self.state == nil || _self.state.meta == nil {
+		return nil, ErrErrorWalletMetadataUnavailable
+	}
+	return _self.state.meta, nil
+}
+
+// walletMeta is the persisted form of the Go layer's wallet metadata.
+type walletMeta struct {
+	// Config is the config passed to CreateWallet.
+	Config *Config `json:"config,omitempty"`
+	// MnemonicFingerprint is checked by WalletMatchesMnemonic.
+	MnemonicFingerprint *mnemonicFingerprint `json:"mnemonic_fingerprint,omitempty"`
+	// Name is set by SetName.
+	Name string `json:"name,omitempty"`
+	// Labels maps addresses handed out by NewAddressLabeled to their label.
+	Labels map[string]string `json:"labels,omitempty"`
+	// AutoRefreshWithinBlocks is set by SetAutoRefreshWithinBlocks.
+	AutoRefreshWithinBlocks *uint32 `json:"auto_refresh_within_blocks,omitempty"`
+	// ExpiresSoonWithinBlocks is set by SetExpiresSoonWithinBlocks.
+	ExpiresSoonWithinBlocks *uint32 `json:"expires_soon_within_blocks,omitempty"`
+	// MaxAspRequestsPerSec is set by SetMaxAspRequestsPerSec.
+	MaxAspRequestsPerSec float64 `json:"max_asp_requests_per_sec,omitempty"`
+	// AspPubkeyPin is set by SetAspPubkeyPin.
+	AspPubkeyPin PublicKey `json:"asp_pubkey_pin,omitempty"`
+	// SendAllowlist is set by SetSendAllowlist.
+	SendAllowlist []string `json:"send_allowlist,omitempty"`
+	// DailySendLimitSat is set by SetDailySendLimit.
+	DailySendLimitSat uint64 `json:"daily_send_limit_sat,omitempty"`
+	// SendLog holds the sends counted against DailySendLimitSat.
+	SendLog []sendRecord `json:"send_log,omitempty"`
+	// ImportedMovements are added by ImportMovements.
+	ImportedMovements []Movement `json:"imported_movements,omitempty"`
+	// MovementRates are set by SetMovementRate, by movement id, and
+	// ImportedMovementRates likewise for imported movements.
+	MovementRates         map[uint32]MovementRate `json:"movement_rates,omitempty"`
+	ImportedMovementRates map[uint32]MovementRate `json:"imported_movement_rates,omitempty"`
+}
+
+type metaStore struct {
+	mu   sync.Mutex
+	path string
+	meta walletMeta
+}
+
+func metaPath(walletPath string) string {
+	if info, err := os.Stat(walletPath); err == nil && info.IsDir() {
+		return filepath.Join(walletPath, metaFileName)
+	}
+	return walletPath + metaFileSuffix
+}
+
+func openMetaStore(walletPath string) (*metaStore, error) {
+	store := &metaStore{path: metaPath(walletPath)}
+	data, err := os.ReadFile(store.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading wallet metadata: %w", err)
+	}
+	if err := json.Unmarshal(data, &store.meta); err != nil {
+		return nil, fmt.Errorf("decoding wallet metadata %s: %w", store.path, err)
+	}
+	return store, nil
+}
+
+// view runs fn with the metadata locked. fn must not retain the pointer.
+func (s *metaStore) view(fn func(meta *walletMeta)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.meta)
+}
+
+// update runs fn on a copy of the metadata and persists the result. If fn or
+// the write fails the in-memory metadata is left untouched.
+func (s *metaStore) update(fn func(meta *walletMeta) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var next walletMeta
+	if err := cloneMeta(&next, &s.meta); err != nil {
+		return err
+	}
+	if err := fn(&next); err != nil {
+		return err
+	}
+	if err := s.write(&next); err != nil {
+		return err
+	}
+	s.meta = next
+	return nil
+}
+
+func (s *metaStore) write(meta *walletMeta) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding wallet metadata: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("writing wallet metadata: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("writing wallet metadata: %w", err)
+	}
+	return nil
+}
+
+// cloneMeta deep-copies src into dst by round-tripping through JSON, which
+// keeps it correct as fields are added.
+func cloneMeta(dst, src *walletMeta) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("encoding wallet metadata: %w", err)
+	}
+	return json.Unmarshal(data, dst)
+}
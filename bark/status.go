@@ -0,0 +1,50 @@
+package bark
+
+// WalletStatus gathers what a wallet dashboard shows.
+type WalletStatus struct {
+	Balance        WalletBalance
+	OnchainBalance OnchainBalance
+	Exit           ExitStatus
+	ArkInfo        ArkInfo
+	// BlockHeight is the chain tip seen by the last sync, nil if the wallet
+	// has not synced since it was opened.
+	BlockHeight *uint32
+	Vtxos       uint32
+	Movements   uint32
+}
+
+// Status returns a snapshot of the wallet for a dashboard. It makes the
+// same native calls a caller would otherwise make one by one, and stops at
+// the first error.
+func (_self *Wallet) Status() (WalletStatus, error) {
+	var status WalletStatus
+	var err error
+
+	if status.Balance, err = _self.WalletBalance(); err != nil {
+		return WalletStatus{}, err
+	}
+	if status.OnchainBalance, err = _self.OnchainBalance(); err != nil {
+		return WalletStatus{}, err
+	}
+	if status.Exit, err = _self.ExitStatus(); err != nil {
+		return WalletStatus{}, err
+	}
+	if status.ArkInfo, err = _self.ArkInfo(); err != nil {
+		return WalletStatus{}, err
+	}
+	vtxos, err := _self.Vtxos()
+	if err != nil {
+		return WalletStatus{}, err
+	}
+	movements, err := _self.Movements()
+	if err != nil {
+		return WalletStatus{}, err
+	}
+	status.Vtxos = uint32(len(vtxos))
+	status.Movements = uint32(len(movements))
+
+	if height := _self.state.syncedHeight.Load(); height != 0 {
+		status.BlockHeight = &height
+	}
+	return status, nil
+}
@@ -0,0 +1,105 @@
+package bark
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// StorageUsage describes how much space a wallet takes up on disk.
+type StorageUsage struct {
+	// DbBytes is the size of the wallet database, including SQLite's
+	// journal files. If the wallet path is a directory it is the size of
+	// everything in it except the metadata file.
+	DbBytes uint64
+	// MetadataBytes is the size of the Go layer's metadata file.
+	MetadataBytes       uint64
+	Movements           uint32
+	Vtxos               uint32
+	OnchainTransactions uint32
+}
+
+// StorageUsage reports the wallet's on-disk size along with the number of
+// records it holds.
+func (_self *Wallet) StorageUsage() (StorageUsage, error) {
+	store, err := _self.metaStore()
+	if err != nil {
+		return StorageUsage{}, err
+	}
+	dbBytes, err := pathSize(_self.state.path, store.path)
+	if err != nil {
+		return StorageUsage{}, err
+	}
+	metaBytes, err := fileSize(store.path)
+	if err != nil {
+		return StorageUsage{}, err
+	}
+
+	movements, err := _self.movementsNative()
+	if err != nil {
+		return StorageUsage{}, err
+	}
+	vtxos, err := _self.Vtxos()
+	if err != nil {
+		return StorageUsage{}, err
+	}
+	transactions := _self.OnchainTransactions()
+
+	return StorageUsage{
+		DbBytes:             dbBytes,
+		MetadataBytes:       metaBytes,
+		Movements:           uint32(len(movements)),
+		Vtxos:               uint32(len(vtxos)),
+		OnchainTransactions: uint32(len(transactions)),
+	}, nil
+}
+
+// pathSize returns the size of the database at path, leaving out the file
+// named by skip.
+func pathSize(path string, skip string) (uint64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	if !info.IsDir() {
+		var total uint64
+		for _, suffix := range []string{"", "-wal", "-shm", "-journal"} {
+			size, err := fileSize(path + suffix)
+			if err != nil {
+				return 0, err
+			}
+			total += size
+		}
+		return total, nil
+	}
+
+	var total uint64
+	err = filepath.WalkDir(path, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type().IsRegular() && name != skip {
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			total += uint64(info.Size())
+		}
+		return nil
+	})
+	return total, err
+}
+
+// fileSize returns the size of the file at path, or zero if it does not
+// exist.
+func fileSize(path string) (uint64, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return uint64(info.Size()), nil
+}
@@ -0,0 +1,54 @@
+package bark
+
+import "io"
+
+// MovementsStream calls cb with each of the wallet's movements, decoding
+// them one at a time instead of building the whole slice, and stops at the
+// first error cb returns, which it returns as is.
+//
+// Movements come in the order the native library returns them rather than
+// that of Movements, followed by those added with ImportMovements, and carry
+// their rates like Movements does. The native library still hands over all
+// movements in one serialized buffer, so only the decoded form is bounded.
+func (_self *Wallet) MovementsStream(cb func(Movement) error) (err error) {
+	defer logCall("MovementsStream")(&err)
+	rbuf, err := _self.movementsBufferNative()
+	if err != nil {
+		return err
+	}
+	defer rbuf.Free()
+
+	var (
+		imported []Movement
+		rates    movementRates
+	)
+	if store, err := _self.metaStore(); err == nil {
+		store.view(func(meta *walletMeta) {
+			imported, rates = movementMetadata(meta)
+		})
+	}
+	return streamMovements(rbuf.AsReader(), imported, rates, cb)
+}
+
+// streamMovements does the work of MovementsStream on reader, a serialized
+// sequence of movements, with the imported movements and rates from the
+// wallet's metadata.
+func streamMovements(reader io.Reader, imported []Movement, rates movementRates, cb func(Movement) error) error {
+	emit := func(movement Movement) error {
+		rates.apply(&movement)
+		return cb(movement)
+	}
+
+	length := readInt32(reader)
+	for i := int32(0); i < length; i++ {
+		if err := emit(FfiConverterMovementINSTANCE.Read(reader)); err != nil {
+			return err
+		}
+	}
+	for _, movement := range imported {
+		if err := emit(movement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package bark
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// movementSequence serializes movements as the native library sends them.
+func movementSequence(movements []Movement) *bytes.Reader {
+	var buf bytes.Buffer
+	FfiConverterSequenceMovementINSTANCE.Write(&buf, movements)
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestStreamMovementsLarge(t *testing.T) {
+	const n = 50_000
+	movements := testLowerMovements(n)
+	var count int
+	var sum uint64
+	err := streamMovements(movementSequence(movements), nil, movementRates{}, func(movement Movement) error {
+		if movement.Id != uint32(count+1) {
+			t.Fatalf("movement %d has id %d", count, movement.Id)
+		}
+		count++
+		sum += movement.AmountReceivedSat
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != n {
+		t.Errorf("streamed %d movements, want %d", count, n)
+	}
+	if want := uint64(1_000) * n * (n + 1) / 2; sum != want {
+		t.Errorf("received %d sat in total, want %d", sum, want)
+	}
+}
+
+func TestStreamMovementsEarlyStop(t *testing.T) {
+	errStop := errors.New("stop")
+	reader := movementSequence(testLowerMovements(50_000))
+	var count int
+	err := streamMovements(reader, nil, movementRates{}, func(Movement) error {
+		count++
+		if count == 100 {
+			return errStop
+		}
+		return nil
+	})
+	if err != errStop {
+		t.Fatalf("error = %v, want the callback's error as is", err)
+	}
+	if count != 100 {
+		t.Errorf("callback ran %d times, want 100", count)
+	}
+	if reader.Len() == 0 {
+		t.Error("the whole sequence was decoded despite the early stop")
+	}
+}
+
+func TestStreamMovementsImportedAndRates(t *testing.T) {
+	native := testLowerMovements(3)
+	imported := []Movement{
+		{Id: 2, Kind: MovementKindBoard, Imported: true},
+		{Id: 100, Kind: MovementKindBoard, CreatedAt: "2023-01-01 00:00:00", Imported: true},
+	}
+	rates := movementRates{
+		native: map[uint32]MovementRate{
+			1: {Currency: "USD", Rate: 60_000},
+			2: {Currency: "GBP", Rate: 50_000},
+		},
+		imported: map[uint32]MovementRate{
+			2: {Currency: "EUR", Rate: 20_000},
+		},
+	}
+	var streamed []Movement
+	err := streamMovements(movementSequence(native), imported, rates, func(movement Movement) error {
+		streamed = append(streamed, movement)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := movementIds(streamed), []uint32{1, 2, 3, 2, 100}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ids = %v, want %v", got, want)
+	}
+	if streamed[1].Imported || streamed[1].Kind != MovementKindArkoorReceive {
+		t.Errorf("movement 1 = %+v, want the native movement 2", streamed[1])
+	}
+	if !streamed[3].Imported || streamed[3].Kind != MovementKindBoard {
+		t.Errorf("movement 3 = %+v, want the imported movement 2", streamed[3])
+	}
+	rate := func(r MovementRate) *MovementRate { return &r }
+	for i, want := range []*MovementRate{
+		rate(rates.native[1]), rate(rates.native[2]), nil, rate(rates.imported[2]), nil,
+	} {
+		if got := streamed[i].Rate; !reflect.DeepEqual(got, want) {
+			t.Errorf("movement %d rate = %v, want %v", i, got, want)
+		}
+	}
+}
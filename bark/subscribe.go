@@ -0,0 +1,111 @@
+package bark
+
+import (
+	"sync"
+	"time"
+)
+
+// subscribePollInterval is how often a subscription checks for changes.
+const subscribePollInterval = 5 * time.Second
+
+// WalletEventKind tells the events sent by Subscribe apart.
+type WalletEventKind uint
+
+const (
+	// WalletEventMovement is a new movement other than a board.
+	WalletEventMovement WalletEventKind = iota + 1
+	// WalletEventBoard is a new board movement. It is sent once the board is
+	// recorded, which is before its funding transaction confirms.
+	WalletEventBoard
+	// WalletEventExitCompleted is sent when ExitStatus reports the exit
+	// done.
+	WalletEventExitCompleted
+)
+
+// WalletEvent is sent by Subscribe.
+type WalletEvent struct {
+	Kind WalletEventKind
+	// Movement is set for WalletEventMovement and WalletEventBoard.
+	Movement *Movement
+}
+
+// Subscribe returns a channel of wallet events and a function that cancels
+// the subscription. The cancel function must be called to stop the
+// subscription's goroutine; it closes the channel and is safe to call more
+// than once. The subscription holds its own Handle, so the wallet stays
+// open until it is cancelled.
+//
+// The native library has no event stream, so the subscription polls the
+// wallet's local state every subscribePollInterval. It does not sync:
+// events appear once a Sync or Maintenance has picked up the change.
+// Events already present when Subscribe is called are not sent, and
+// sending blocks until the receiver is ready.
+func (_self *Wallet) Subscribe() (<-chan WalletEvent, func()) {
+	events := make(chan WalletEvent)
+	stop := make(chan struct{})
+	var once sync.Once
+	cancel := func() { once.Do(func() { close(stop) }) }
+
+	wallet := _self.Handle()
+	known, _ := wallet.Movements()
+	exitDone := false
+	if status, err := wallet.ExitStatus(); err == nil {
+		exitDone = status.Done
+	}
+
+	go func() {
+		defer close(events)
+		defer wallet.Destroy()
+		ticker := time.NewTicker(subscribePollInterval)
+		defer ticker.Stop()
+		pollEvents(wallet, known, exitDone, ticker.C, stop, events)
+	}()
+	return events, cancel
+}
+
+// eventSource is the part of a wallet a subscription polls.
+type eventSource interface {
+	Movements() ([]Movement, error)
+	ExitStatus() (ExitStatus, error)
+}
+
+// pollEvents checks source on every tick and sends what changed since the
+// last check to events, until stop is closed. known and exitDone are what
+// was seen when the subscription started.
+func pollEvents(source eventSource, known []Movement, exitDone bool, tick <-chan time.Time, stop <-chan struct{}, events chan<- WalletEvent) {
+	send := func(event WalletEvent) bool {
+		select {
+		case events <- event:
+			return true
+		case <-stop:
+			return false
+		}
+	}
+	for {
+		select {
+		case <-stop:
+			return
+		case <-tick:
+		}
+		if movements, err := source.Movements(); err == nil {
+			for _, movement := range newMovements(known, movements) {
+				kind := WalletEventMovement
+				if movement.Kind == MovementKindBoard {
+					kind = WalletEventBoard
+				}
+				if !send(WalletEvent{Kind: kind, Movement: &movement}) {
+					return
+				}
+			}
+			known = movements
+		}
+		if status, err := source.ExitStatus(); err == nil {
+			if status.Done && !exitDone {
+				if !send(WalletEvent{Kind: WalletEventExitCompleted}) {
+					return
+				}
+			}
+			exitDone = status.Done
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package bark
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeEventSource is an eventSource whose state tests change between ticks.
+type fakeEventSource struct {
+	mu        sync.Mutex
+	movements []Movement
+	exitDone  bool
+}
+
+func (s *fakeEventSource) Movements() ([]Movement, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Movement(nil), s.movements...), nil
+}
+
+func (s *fakeEventSource) ExitStatus() (ExitStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ExitStatus{Done: s.exitDone}, nil
+}
+
+func (s *fakeEventSource) add(movement Movement) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.movements = append([]Movement{movement}, s.movements...)
+}
+
+// startPolling runs pollEvents on source until the test ends and returns
+// the channels that drive it.
+func startPolling(t *testing.T, source *fakeEventSource) (chan<- time.Time, <-chan WalletEvent) {
+	t.Helper()
+	known, _ := source.Movements()
+	tick := make(chan time.Time)
+	stop := make(chan struct{})
+	events := make(chan WalletEvent)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pollEvents(source, known, false, tick, stop, events)
+	}()
+	t.Cleanup(func() {
+		close(stop)
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Error("pollEvents did not return after stop")
+		}
+	})
+	return tick, events
+}
+
+func receiveEvent(t *testing.T, events <-chan WalletEvent) WalletEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event")
+		return WalletEvent{}
+	}
+}
+
+func TestPollEventsIncomingMovement(t *testing.T) {
+	source := &fakeEventSource{movements: []Movement{{Id: 1, Kind: MovementKindBoard}}}
+	tick, events := startPolling(t, source)
+
+	source.add(Movement{Id: 2, Kind: MovementKindArkoorReceive, AmountReceivedSat: 5_000})
+	tick <- time.Now()
+	event := receiveEvent(t, events)
+	if event.Kind != WalletEventMovement || event.Movement == nil || event.Movement.Id != 2 {
+		t.Fatalf("event = %+v, want movement 2", event)
+	}
+	if event.Movement.AmountReceivedSat != 5_000 {
+		t.Errorf("received %d sat, want 5000", event.Movement.AmountReceivedSat)
+	}
+
+	// A tick with nothing new sends nothing.
+	tick <- time.Now()
+	tick <- time.Now()
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event %+v", event)
+	default:
+	}
+}
+
+func TestPollEventsBoardAndExit(t *testing.T) {
+	source := &fakeEventSource{}
+	tick, events := startPolling(t, source)
+
+	source.add(Movement{Id: 1, Kind: MovementKindBoard})
+	source.mu.Lock()
+	source.exitDone = true
+	source.mu.Unlock()
+	tick <- time.Now()
+
+	if event := receiveEvent(t, events); event.Kind != WalletEventBoard || event.Movement.Id != 1 {
+		t.Errorf("first event = %+v, want board 1 confirmed", event)
+	}
+	if event := receiveEvent(t, events); event.Kind != WalletEventExitCompleted {
+		t.Errorf("second event = %+v, want the exit completed", event)
+	}
+}
@@ -0,0 +1,134 @@
+package bark
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Sync syncs the wallet with the ASP and the chain source, then runs the
+// Go layer's post-sync work such as automatic refreshes.
+func (_self *Wallet) Sync() error {
+	return _self.SyncContext(context.Background())
+}
+
+// SyncContext is Sync, returning ctx.Err() if ctx is done before the sync
+// finishes. The native call cannot be interrupted, so an abandoned sync
+// still runs to completion in the background and observers are told its
+// real outcome.
+func (_self *Wallet) SyncContext(ctx context.Context) (err error) {
+	defer logCall("Sync")(&err)
+	return callWithContextErr(ctx, func() error {
+		err := _self.sync()
+		_self.notifyObservers(err)
+		return err
+	})
+}
+
+func (_self *Wallet) sync() error {
+	started := time.Now()
+	if err := _self.syncNative(); err != nil {
+		return err
+	}
+	_self.state.syncTimings.record(started, time.Since(started))
+	return _self.afterSync()
+}
+
+// Maintenance runs the native maintenance routine followed by the same
+// post-sync work as Sync.
+func (_self *Wallet) Maintenance() (err error) {
+	defer logCall("Maintenance")(&err)
+	err = _self.maintenance()
+	_self.notifyObservers(err)
+	return err
+}
+
+func (_self *Wallet) maintenance() error {
+	if err := _self.maintenanceNative(); err != nil {
+		return err
+	}
+	return _self.afterSync()
+}
+
+func (_self *Wallet) afterSync() error {
+	_self.state.lastAutoRefresh.Store(0)
+
+	if _self.aspPubkeyPin() != "" {
+		info, err := _self.ArkInfo()
+		if err != nil {
+			return err
+		}
+		if err := _self.checkAspPubkey(info.AspPubkey); err != nil {
+			return err
+		}
+	}
+
+	esplora, err := _self.esplora()
+	if err != nil {
+		// Without a known chain source there is nothing more to do; this is
+		// the case for wallets created before the config was recorded.
+		return nil
+	}
+	height, err := esplora.tipHeight(context.Background())
+	if err != nil {
+		// The native sync succeeded; only fail if a post-sync step needs
+		// the height.
+		if _self.needsTipHeight() {
+			return err
+		}
+		return nil
+	}
+	_self.state.syncedHeight.Store(height)
+
+	if err := _self.notifyExpiring(height); err != nil {
+		return err
+	}
+	return _self.autoRefresh(height)
+}
+
+func (_self *Wallet) needsTipHeight() bool {
+	_, autoRefresh := _self.autoRefreshWithinBlocks()
+	return autoRefresh || _self.hasExpiryWatch()
+}
+
+func (_self *Wallet) autoRefresh(height uint32) error {
+	within, ok := _self.autoRefreshWithinBlocks()
+	if !ok {
+		return nil
+	}
+	vtxos, err := _self.Vtxos()
+	if err != nil {
+		return err
+	}
+	if len(expiringVtxos(vtxos, height, within)) == 0 {
+		return nil
+	}
+	if err := _self.RefreshAll(); err != nil {
+		return fmt.Errorf("auto-refreshing vtxos: %w", err)
+	}
+	_self.state.lastAutoRefresh.Store(uint32(len(vtxos)))
+	return nil
+}
+
+// expiringVtxos returns the vtxos that expire within the given number of
+// blocks of height, including those already expired.
+func expiringVtxos(vtxos []Vtxo, height uint32, within uint32) []Vtxo {
+	var expiring []Vtxo
+	for _, vtxo := range vtxos {
+		if uint64(vtxo.ExpiryHeight) <= uint64(height)+uint64(within) {
+			expiring = append(expiring, vtxo)
+		}
+	}
+	return expiring
+}
+
+// BlockHeight returns the chain tip seen by the last successful Sync or
+// Maintenance. The height is kept in memory only, so it fails with
+// ErrErrorNoSyncHistory until the wallet has synced since it was opened.
+func (_self *Wallet) BlockHeight() (uint32, error) {
+	height := _self.state.syncedHeight.Load()
+	if height == 0 {
+		return 0, fmt.Errorf("%w: the wallet has not synced since it was opened", ErrErrorNoSyncHistory)
+	}
+	return height, nil
+}
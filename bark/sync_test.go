@@ -0,0 +1,47 @@
+package bark
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestBlockHeightNeverSynced(t *testing.T) {
+	wallet, _ := newTestWallet(t)
+	if _, err := wallet.BlockHeight(); !errors.Is(err, ErrErrorNoSyncHistory) {
+		t.Fatalf("BlockHeight error = %v, want ErrErrorNoSyncHistory", err)
+	}
+}
+
+func TestBlockHeightAfterSync(t *testing.T) {
+	wallet, _ := newTestWallet(t)
+	withTestMeta(t, wallet)
+	withTestEsplora(t, wallet, esploraTip("850123\n"))
+
+	if err := wallet.afterSync(); err != nil {
+		t.Fatal(err)
+	}
+	height, err := wallet.BlockHeight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height != 850123 {
+		t.Errorf("BlockHeight = %d, want 850123", height)
+	}
+}
+
+func TestBlockHeightEsploraDown(t *testing.T) {
+	wallet, _ := newTestWallet(t)
+	withTestMeta(t, wallet)
+	withTestEsplora(t, wallet, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+
+	// The sync itself succeeded, so nothing that needs the height fails it.
+	if err := wallet.afterSync(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wallet.BlockHeight(); !errors.Is(err, ErrErrorNoSyncHistory) {
+		t.Fatalf("BlockHeight error = %v, want ErrErrorNoSyncHistory", err)
+	}
+}
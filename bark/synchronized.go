@@ -0,0 +1,157 @@
+package bark
+
+import "sync"
+
+// SynchronizedWallet wraps a wallet so that methods which change its state,
+// or talk to the ASP on its behalf, run one at a time, while read-only
+// methods such as WalletBalance and Vtxos may run concurrently with each
+// other. A Wallet on its own is safe to call from several goroutines but
+// does not stop, say, a Send and a BoardAll from racing in the same round.
+type SynchronizedWallet struct {
+	WalletInterface
+	mu sync.RWMutex
+}
+
+// NewSynchronizedWallet returns a SynchronizedWallet wrapping wallet. Calls
+// made on wallet directly are not serialized.
+func NewSynchronizedWallet(wallet *Wallet) *SynchronizedWallet {
+	return &SynchronizedWallet{WalletInterface: wallet}
+}
+
+var _ WalletInterface = (*SynchronizedWallet)(nil)
+
+// Read-only methods share the lock.
+
+func (w *SynchronizedWallet) ArkInfo() (ArkInfo, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.WalletInterface.ArkInfo()
+}
+
+func (w *SynchronizedWallet) ExitStatus() (ExitStatus, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.WalletInterface.ExitStatus()
+}
+
+func (w *SynchronizedWallet) LookupInvoice(paymentHash PaymentHash) (*LightningReceive, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.WalletInterface.LookupInvoice(paymentHash)
+}
+
+func (w *SynchronizedWallet) Movements() ([]Movement, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.WalletInterface.Movements()
+}
+
+func (w *SynchronizedWallet) OnchainBalance() (OnchainBalance, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.WalletInterface.OnchainBalance()
+}
+
+func (w *SynchronizedWallet) OnchainTransactions() []OnchainTransaction {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.WalletInterface.OnchainTransactions()
+}
+
+func (w *SynchronizedWallet) Utxos() []Utxo {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.WalletInterface.Utxos()
+}
+
+func (w *SynchronizedWallet) Vtxos() ([]Vtxo, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.WalletInterface.Vtxos()
+}
+
+func (w *SynchronizedWallet) WalletBalance() (WalletBalance, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.WalletInterface.WalletBalance()
+}
+
+// The remaining methods hold the lock exclusively.
+
+func (w *SynchronizedWallet) BoardAll() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.WalletInterface.BoardAll()
+}
+
+func (w *SynchronizedWallet) Bolt11Invoice(amountSats uint64) (Bolt11Invoice, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.WalletInterface.Bolt11Invoice(amountSats)
+}
+
+func (w *SynchronizedWallet) ClaimBolt11Payment(invoice Bolt11Invoice) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.WalletInterface.ClaimBolt11Payment(invoice)
+}
+
+func (w *SynchronizedWallet) ExitAll() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.WalletInterface.ExitAll()
+}
+
+func (w *SynchronizedWallet) Maintenance() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.WalletInterface.Maintenance()
+}
+
+func (w *SynchronizedWallet) NewAddress() (BarkAddress, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.WalletInterface.NewAddress()
+}
+
+func (w *SynchronizedWallet) OffboardAll() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.WalletInterface.OffboardAll()
+}
+
+func (w *SynchronizedWallet) OnchainAddress() (string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.WalletInterface.OnchainAddress()
+}
+
+func (w *SynchronizedWallet) PayBolt11(invoice Bolt11Invoice, amountSats *uint64) (string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.WalletInterface.PayBolt11(invoice, amountSats)
+}
+
+func (w *SynchronizedWallet) RefreshAll() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.WalletInterface.RefreshAll()
+}
+
+func (w *SynchronizedWallet) Send(destination BarkAddress, amountSats uint64) ([]Vtxo, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.WalletInterface.Send(destination, amountSats)
+}
+
+func (w *SynchronizedWallet) SendOnchain(address string, amountSats uint64) (string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.WalletInterface.SendOnchain(address, amountSats)
+}
+
+func (w *SynchronizedWallet) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.WalletInterface.Sync()
+}
@@ -0,0 +1,104 @@
+package bark
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stubWallet is a WalletInterface that records how calls overlap. Its
+// balance is a plain field so that the race detector flags any write that
+// is not serialized against other calls.
+type stubWallet struct {
+	WalletInterface
+
+	readers     atomic.Int32
+	writers     atomic.Int32
+	maxReaders  atomic.Int32
+	overlapping atomic.Bool
+
+	balance uint64
+}
+
+func (w *stubWallet) enter(write bool) func() {
+	if write {
+		if w.writers.Add(1) > 1 || w.readers.Load() > 0 {
+			w.overlapping.Store(true)
+		}
+		return func() { w.writers.Add(-1) }
+	}
+	readers := w.readers.Add(1)
+	if w.writers.Load() > 0 {
+		w.overlapping.Store(true)
+	}
+	for {
+		max := w.maxReaders.Load()
+		if readers <= max || w.maxReaders.CompareAndSwap(max, readers) {
+			break
+		}
+	}
+	return func() { w.readers.Add(-1) }
+}
+
+func (w *stubWallet) WalletBalance() (WalletBalance, error) {
+	defer w.enter(false)()
+	time.Sleep(time.Millisecond)
+	return WalletBalance{SpendableSat: w.balance}, nil
+}
+
+func (w *stubWallet) Vtxos() ([]Vtxo, error) {
+	defer w.enter(false)()
+	time.Sleep(time.Millisecond)
+	return []Vtxo{{AmountSat: w.balance}}, nil
+}
+
+func (w *stubWallet) Send(destination BarkAddress, amountSats uint64) ([]Vtxo, error) {
+	defer w.enter(true)()
+	w.balance -= amountSats
+	return nil, nil
+}
+
+func (w *stubWallet) BoardAll() error {
+	defer w.enter(true)()
+	w.balance += 1000
+	return nil
+}
+
+func TestSynchronizedWalletConcurrency(t *testing.T) {
+	stub := &stubWallet{balance: 1_000_000}
+	wallet := &SynchronizedWallet{WalletInterface: stub}
+
+	const goroutines = 32
+	const calls = 48
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range calls {
+				switch (g + i) % 4 {
+				case 0:
+					wallet.WalletBalance()
+				case 1:
+					wallet.Vtxos()
+				case 2:
+					wallet.Send("ark1", 1000)
+				case 3:
+					wallet.BoardAll()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if stub.overlapping.Load() {
+		t.Error("a mutating call overlapped another call")
+	}
+	if stub.maxReaders.Load() < 2 {
+		t.Errorf("at most %d read-only call ran at a time, want concurrent reads", stub.maxReaders.Load())
+	}
+	if want := uint64(1_000_000); stub.balance != want {
+		t.Errorf("balance = %d, want %d", stub.balance, want)
+	}
+}
@@ -0,0 +1,90 @@
+package bark
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// syncHistorySize is the number of recent syncs EstimateSyncDuration
+// averages over.
+const syncHistorySize = 10
+
+// syncTimings records when recent syncs ran and how long they took. When
+// the wallet has a data directory the history is cached there so it
+// survives restarts.
+type syncTimings struct {
+	mu        sync.Mutex
+	entries   []syncTiming
+	cachePath string
+}
+
+type syncTiming struct {
+	Started time.Time     `json:"started"`
+	Took    time.Duration `json:"took"`
+}
+
+// load reads the cached history from cachePath. A missing or unreadable
+// cache just means starting over.
+func (t *syncTimings) load(cachePath string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cachePath = cachePath
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		return
+	}
+	var entries []syncTiming
+	if json.Unmarshal(data, &entries) == nil && len(entries) <= syncHistorySize {
+		t.entries = entries
+	}
+}
+
+func (t *syncTimings) record(started time.Time, took time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = append(t.entries, syncTiming{Started: started, Took: took})
+	if len(t.entries) > syncHistorySize {
+		t.entries = t.entries[1:]
+	}
+	if t.cachePath != "" {
+		// Best effort: losing the cache only makes estimates worse.
+		if data, err := json.Marshal(t.entries); err == nil {
+			_ = os.WriteFile(t.cachePath, data, 0o600)
+		}
+	}
+}
+
+// EstimateSyncDuration returns a rough estimate of how long the next Sync
+// will take. It averages the durations of recent successful syncs and
+// scales the result up, by at most four times, when the wallet has gone
+// longer than usual without syncing, since there is more to catch up on.
+// Timings are kept in memory, or in Config.DataDir when set, and
+// ErrErrorNoSyncHistory is returned until there is at least one.
+func (_self *Wallet) EstimateSyncDuration() (time.Duration, error) {
+	timings := &_self.state.syncTimings
+	timings.mu.Lock()
+	defer timings.mu.Unlock()
+
+	entries := timings.entries
+	n := len(entries)
+	if n == 0 {
+		return 0, ErrErrorNoSyncHistory
+	}
+	var total time.Duration
+	for _, entry := range entries {
+		total += entry.Took
+	}
+	estimate := total / time.Duration(n)
+
+	if n > 1 {
+		interval := entries[n-1].Started.Sub(entries[0].Started) / time.Duration(n-1)
+		since := time.Since(entries[n-1].Started)
+		if interval > 0 && since > interval {
+			scale := min(float64(since)/float64(interval), 4)
+			estimate = time.Duration(float64(estimate) * scale)
+		}
+	}
+	return estimate, nil
+}
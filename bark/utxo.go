@@ -0,0 +1,30 @@
+package bark
+
+// UtxoKind identifies the variant of a Utxo.
+type UtxoKind uint
+
+const (
+	// UtxoKindLocal is a UtxoLocal, an output of the onchain wallet.
+	UtxoKindLocal UtxoKind = iota + 1
+	// UtxoKindExit is a UtxoExit, a vtxo claimed onchain by an exit.
+	UtxoKindExit
+)
+
+func (e UtxoLocal) Kind() UtxoKind {
+	return UtxoKindLocal
+}
+
+// ValueSat returns the output's AmountSat. The method cannot be called
+// AmountSat because UtxoLocal has a field of that name.
+func (e UtxoLocal) ValueSat() uint64 {
+	return e.AmountSat
+}
+
+func (e UtxoExit) Kind() UtxoKind {
+	return UtxoKindExit
+}
+
+// ValueSat returns the amount of the exited vtxo.
+func (e UtxoExit) ValueSat() uint64 {
+	return e.Vtxo.AmountSat
+}
@@ -0,0 +1,25 @@
+package bark
+
+import "testing"
+
+func TestUtxoSumMixed(t *testing.T) {
+	height := uint32(800_000)
+	utxos := []Utxo{
+		UtxoLocal{Outpoint: OutPoint{Txid: "aa", Vout: 0}, AmountSat: 10_000, ConfirmationHeight: &height},
+		UtxoExit{Vtxo: Vtxo{AmountSat: 25_000}, Height: 800_100},
+		UtxoLocal{Outpoint: OutPoint{Txid: "bb", Vout: 1}, AmountSat: 5_000},
+		UtxoExit{Vtxo: Vtxo{AmountSat: 1_000}, Height: 800_200},
+	}
+	var total uint64
+	totals := map[UtxoKind]uint64{}
+	for _, utxo := range utxos {
+		total += utxo.ValueSat()
+		totals[utxo.Kind()] += utxo.ValueSat()
+	}
+	if total != 41_000 {
+		t.Errorf("total = %d, want 41000", total)
+	}
+	if totals[UtxoKindLocal] != 15_000 || totals[UtxoKindExit] != 26_000 {
+		t.Errorf("totals by kind = %v, want 15000 local and 26000 exit", totals)
+	}
+}
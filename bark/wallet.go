@@ -0,0 +1,125 @@
+package bark
+
+import (
+	"errors"
+	"runtime"
+	"time"
+)
+
+// DefaultDbBusyTimeoutMs is used when Config.DbBusyTimeoutMs is nil.
+const DefaultDbBusyTimeoutMs = 5000
+
+// CreateWallet creates a new wallet at path from mnemonic. It fails with
+// ErrErrorWalletAlreadyOpen if a wallet at path is already open in this
+// process.
+func CreateWallet(path string, mnemonic string, config Config) (*Wallet, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.DataDir != nil {
+		if err := checkDataDir(*config.DataDir); err != nil {
+			return nil, err
+		}
+	}
+	key, err := registerWallet(path)
+	if err != nil {
+		return nil, err
+	}
+	wallet, err := retryWhileBusy(busyTimeout(&config), func() (*Wallet, error) {
+		return createWalletNative(path, mnemonic, config)
+	})
+	if err != nil {
+		unregisterWallet(key)
+		return nil, err
+	}
+	wallet.state.registryKey = key
+	if err := attachWalletState(wallet, path, mnemonic, &config); err != nil {
+		wallet.Destroy()
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// OpenWallet opens the existing wallet at path. Like CreateWallet it
+// fails with ErrErrorWalletAlreadyOpen if the wallet is already open in this
+// process; Destroy the other wallet first.
+func OpenWallet(path string, mnemonic string) (*Wallet, error) {
+	// The busy timeout comes from the config recorded at creation.
+	var config *Config
+	if store, err := openMetaStore(path); err == nil {
+		store.view(func(meta *walletMeta) {
+			config = meta.Config
+		})
+	}
+	// Check it the way CreateWallet did, before touching the database.
+	if config != nil {
+		checked := *config
+		if err := checked.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	key, err := registerWallet(path)
+	if err != nil {
+		return nil, err
+	}
+	wallet, err := retryWhileBusy(busyTimeout(config), func() (*Wallet, error) {
+		return openWalletNative(path, mnemonic)
+	})
+	if err != nil {
+		unregisterWallet(key)
+		return nil, err
+	}
+	wallet.state.registryKey = key
+	if err := attachWalletState(wallet, path, mnemonic, nil); err != nil {
+		wallet.Destroy()
+		return nil, err
+	}
+	return wallet, nil
+}
+
+func busyTimeout(config *Config) time.Duration {
+	ms := uint32(DefaultDbBusyTimeoutMs)
+	if config != nil && config.DbBusyTimeoutMs != nil {
+		ms = *config.DbBusyTimeoutMs
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// retryWhileBusy calls open until it stops failing with
+// ErrErrorBarkDbFileNotAccessible or timeout has passed.
+func retryWhileBusy(timeout time.Duration, open func() (*Wallet, error)) (*Wallet, error) {
+	deadline := time.Now().Add(timeout)
+	backoff := 10 * time.Millisecond
+	for {
+		wallet, err := open()
+		if err == nil || !errors.Is(err, ErrErrorBarkDbFileNotAccessible) {
+			return wallet, err
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, err
+		}
+		time.Sleep(min(backoff, remaining))
+		backoff = min(backoff*2, 250*time.Millisecond)
+	}
+}
+
+// Close releases the wallet, like Destroy, for use as defer w.Close(). It
+// returns ErrWalletClosed if the wallet was already closed or destroyed, so
+// a double close does not go unnoticed.
+func (_self *Wallet) Close() error {
+	runtime.SetFinalizer(_self, nil)
+	if !_self.ffiObject.destroy() {
+		return ErrWalletClosed
+	}
+	_self.state.release()
+	return nil
+}
+
+// IsAlive reports whether the wallet can still be called, that is it has not
+// been closed or destroyed. It does not touch the call counter. A wallet that
+// is alive can still be closed by another goroutine right after.
+func (_self *Wallet) IsAlive() bool {
+	return !_self.ffiObject.destroyed.Load() && _self.ffiObject.callCounter.Load() >= 0
+}
@@ -0,0 +1,57 @@
+package bark
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWalletCloseTwice(t *testing.T) {
+	wallet, native := newTestWallet(t)
+	if err := wallet.Close(); err != nil {
+		t.Fatalf("first Close: %v", err)
+	}
+	if native.alive() {
+		t.Error("Close did not free the native object")
+	}
+	if err := wallet.Close(); !errors.Is(err, ErrWalletClosed) {
+		t.Errorf("second Close = %v, want ErrWalletClosed", err)
+	}
+}
+
+func TestWalletCloseAfterDestroy(t *testing.T) {
+	wallet, _ := newTestWallet(t)
+	wallet.Destroy()
+	if err := wallet.Close(); !errors.Is(err, ErrWalletClosed) {
+		t.Errorf("Close after Destroy = %v, want ErrWalletClosed", err)
+	}
+	// Destroy stays safe to call again, as the finalizer may.
+	wallet.Destroy()
+}
+
+func TestWalletIsAlive(t *testing.T) {
+	wallet, native := newTestWallet(t)
+	if !wallet.IsAlive() {
+		t.Fatal("new wallet is not alive")
+	}
+	refs := native.refs.Load()
+	counter := wallet.ffiObject.callCounter.Load()
+	wallet.IsAlive()
+	if native.refs.Load() != refs || wallet.ffiObject.callCounter.Load() != counter {
+		t.Error("IsAlive changed the reference count or call counter")
+	}
+
+	wallet.Destroy()
+	if wallet.IsAlive() {
+		t.Error("wallet is alive after Destroy")
+	}
+}
+
+func TestWalletIsAliveAfterClose(t *testing.T) {
+	wallet, _ := newTestWallet(t)
+	if err := wallet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if wallet.IsAlive() {
+		t.Error("wallet is alive after Close")
+	}
+}